@@ -0,0 +1,127 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CircuitBreaker stops requests to a failing api. After Threshold
+// consecutive failures it opens, fast-failing requests with
+// ErrCircuitOpen. Once Cooldown has passed it lets a single probe
+// request through, closing again if the probe succeeds and reopening
+// if it fails. Network errors, rate limits and server errors count
+// as failures, while other error responses, like not found, don't.
+type CircuitBreaker struct {
+	// Threshold is the number of consecutive failures
+	// that open the breaker. Defaults to 5 if not positive.
+	Threshold int
+	// Cooldown is how long the breaker stays open before
+	// probing the api. Defaults to 30s if not positive.
+	Cooldown time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+	now      func() time.Time
+}
+
+const (
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+// NewCircuitBreaker returns a circuit breaker opening after
+// threshold consecutive failures for the given cooldown.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{Threshold: threshold, Cooldown: cooldown}
+}
+
+// Open reports whether the breaker is currently failing requests.
+func (b *CircuitBreaker) Open() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failures >= b.threshold() && (b.probing || b.clock().Sub(b.openedAt) < b.cooldown())
+}
+
+// allow returns ErrCircuitOpen if a request may not be sent, and
+// whether the request is a probe. Once the cooldown has passed,
+// only one probe is let through at a time.
+func (b *CircuitBreaker) allow() (probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold() {
+		return false, nil
+	}
+	if b.probing || b.clock().Sub(b.openedAt) < b.cooldown() {
+		return false, ErrCircuitOpen
+	}
+	b.probing = true
+	return true, nil
+}
+
+// record updates the breaker with the outcome of a request.
+func (b *CircuitBreaker) record(probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probing = false
+	}
+
+	switch {
+	case isBreakerFailure(err):
+		b.failures++
+		if probe || b.failures == b.threshold() {
+			b.openedAt = b.clock()
+		}
+	case !isCanceled(err):
+		// The api answered, so it's up.
+		b.failures = 0
+	}
+}
+
+// isBreakerFailure reports whether an error means the api is unavailable.
+// Requests canceled by their context and client errors are not failures.
+func isBreakerFailure(err error) bool {
+	if err == nil || isCanceled(err) {
+		return false
+	}
+
+	var remoteErr *RemoteError
+	if !errors.As(err, &remoteErr) {
+		return true
+	}
+	return remoteErr.StatusCode == http.StatusTooManyRequests || remoteErr.StatusCode >= 500
+}
+
+// isCanceled reports whether a request
+// failed because its context is done.
+func isCanceled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func (b *CircuitBreaker) threshold() int {
+	if b.Threshold <= 0 {
+		return defaultBreakerThreshold
+	}
+	return b.Threshold
+}
+
+func (b *CircuitBreaker) cooldown() time.Duration {
+	if b.Cooldown <= 0 {
+		return defaultBreakerCooldown
+	}
+	return b.Cooldown
+}
+
+func (b *CircuitBreaker) clock() time.Time {
+	if b.now != nil {
+		return b.now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,95 @@
+package finance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusServiceUnavailable)
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(int(status.Load()))
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	breaker := NewCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	b := newTestBackend(t, server)
+	b.Breaker = breaker
+	call := func() error { return b.Call("/v7/finance/quote", nil, nil, &struct{}{}) }
+
+	assert.NotErrorIs(t, call(), ErrCircuitOpen)
+	assert.False(t, breaker.Open())
+	assert.NotErrorIs(t, call(), ErrCircuitOpen)
+	assert.True(t, breaker.Open())
+
+	// Open: fast-fail without a request.
+	assert.ErrorIs(t, call(), ErrCircuitOpen)
+	assert.Equal(t, int32(2), requests.Load())
+
+	// Half-open: a failed probe reopens the breaker.
+	now = now.Add(time.Minute)
+	assert.NotErrorIs(t, call(), ErrCircuitOpen)
+	assert.Equal(t, int32(3), requests.Load())
+	assert.ErrorIs(t, call(), ErrCircuitOpen)
+
+	// A successful probe closes it.
+	now = now.Add(time.Minute)
+	status.Store(http.StatusOK)
+	assert.Nil(t, call())
+	assert.False(t, breaker.Open())
+	assert.Nil(t, call())
+	assert.Equal(t, int32(5), requests.Load())
+}
+
+func TestCircuitBreakerFailures(t *testing.T) {
+	b := NewCircuitBreaker(1, time.Minute)
+
+	// Client errors and canceled requests don't open the breaker.
+	for _, err := range []error{
+		&RemoteError{StatusCode: http.StatusNotFound},
+		context.Canceled,
+	} {
+		probe, aerr := b.allow()
+		assert.Nil(t, aerr)
+		b.record(probe, err)
+		assert.False(t, b.Open())
+	}
+
+	probe, _ := b.allow()
+	b.record(probe, &RemoteError{StatusCode: http.StatusTooManyRequests})
+	assert.True(t, b.Open())
+}
+
+func TestCircuitBreakerSingleProbe(t *testing.T) {
+	now := time.Now()
+	b := NewCircuitBreaker(1, time.Minute)
+	b.now = func() time.Time { return now }
+	b.record(false, &RemoteError{StatusCode: http.StatusBadGateway})
+
+	now = now.Add(time.Minute)
+	probe, err := b.allow()
+	assert.True(t, probe)
+	assert.Nil(t, err)
+
+	// Only one probe is in flight at a time.
+	_, err = b.allow()
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	b.record(probe, nil)
+	probe, err = b.allow()
+	assert.False(t, probe)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,147 @@
+package finance
+
+import (
+	"container/list"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long responses are cached for
+// endpoints without a ttl set with SetCacheTTL. It is short
+// since quote data is near-realtime.
+const DefaultCacheTTL = time.Second
+
+// Cache stores api response bodies.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, body []byte, ttl time.Duration)
+}
+
+var responseCache struct {
+	c    Cache
+	ttls map[string]time.Duration
+	mu   sync.RWMutex
+}
+
+// SetCache sets the cache consulted before every api call,
+// keyed by request method and URL. A nil cache, the default,
+// disables caching.
+func SetCache(c Cache) {
+	responseCache.mu.Lock()
+	defer responseCache.mu.Unlock()
+	responseCache.c = c
+}
+
+// SetCacheTTL sets how long responses are cached for request
+// paths starting with prefix, for example "/v8/finance/chart".
+// The longest matching prefix wins. A ttl of 0 or less
+// disables caching for the matching paths.
+func SetCacheTTL(prefix string, ttl time.Duration) {
+	responseCache.mu.Lock()
+	defer responseCache.mu.Unlock()
+	if responseCache.ttls == nil {
+		responseCache.ttls = map[string]time.Duration{}
+	}
+	responseCache.ttls[prefix] = ttl
+}
+
+// getCache returns the current cache and the ttl for a
+// request path, or a nil cache if caching is disabled.
+func getCache(path string) (Cache, time.Duration) {
+	responseCache.mu.RLock()
+	defer responseCache.mu.RUnlock()
+
+	if responseCache.c == nil {
+		return nil, 0
+	}
+
+	ttl, matched := DefaultCacheTTL, ""
+	for prefix, t := range responseCache.ttls {
+		if strings.HasPrefix(path, prefix) && len(prefix) >= len(matched) {
+			ttl, matched = t, prefix
+		}
+	}
+	if ttl <= 0 {
+		return nil, 0
+	}
+	return responseCache.c, ttl
+}
+
+// cacheKey returns the cache key of a request.
+func cacheKey(req *http.Request) string {
+	return req.Method + " " + req.URL.String()
+}
+
+// MemoryCache is an in-memory least recently used Cache.
+type MemoryCache struct {
+	size    int
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// memoryEntry is a cached response body.
+type memoryEntry struct {
+	key     string
+	body    []byte
+	expires time.Time
+}
+
+// NewMemoryCache returns an in-memory cache holding up to size
+// responses, evicting the least recently used ones first.
+func NewMemoryCache(size int) *MemoryCache {
+	if size < 1 {
+		size = 1
+	}
+	return &MemoryCache{
+		size:    size,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+// Get returns the body cached for key, if it hasn't expired.
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := e.Value.(*memoryEntry)
+	if time.Now().After(entry.expires) {
+		c.order.Remove(e)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return entry.body, true
+}
+
+// Set caches body for key for the given ttl.
+func (c *MemoryCache) Set(key string, body []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &memoryEntry{key: key, body: body, expires: time.Now().Add(ttl)}
+	if e, ok := c.entries[key]; ok {
+		e.Value = entry
+		c.order.MoveToFront(e)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryEntry).key)
+	}
+}
@@ -0,0 +1,74 @@
+package calendar
+
+import (
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/datetime"
+	"github.com/fijoyapp/finance-go/quotesummary"
+)
+
+// Client is used to invoke calendar APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and symbol information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Symbol string `form:"-"`
+}
+
+// GetEarnings returns the earnings calendar for a symbol.
+// Symbols without scheduled earnings, such as etfs,
+// return an empty calendar rather than an error.
+func GetEarnings(symbol string) (*finance.EarningsCalendar, error) {
+	return GetEarningsP(&Params{Symbol: symbol})
+}
+
+// GetEarningsP returns an earnings calendar and requires
+// a params struct as an argument.
+func GetEarningsP(params *Params) (*finance.EarningsCalendar, error) {
+	return getC().GetEarningsP(params)
+}
+
+// GetEarningsP returns an earnings calendar.
+func (c Client) GetEarningsP(params *Params) (*finance.EarningsCalendar, error) {
+	if params == nil || len(params.Symbol) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	summary, err := quotesummary.Client{B: c.B}.GetP(&quotesummary.Params{
+		Params:  params.Params,
+		Symbol:  params.Symbol,
+		Modules: []string{quotesummary.ModuleCalendarEvents, quotesummary.ModuleEarnings},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cal := &finance.EarningsCalendar{Symbol: params.Symbol}
+
+	if events := summary.CalendarEvents; events != nil {
+		dates := events.Earnings.EarningsDate
+		if len(dates) > 0 {
+			cal.EarningsStart = datetime.FromUnix(dates[0])
+			cal.EarningsEnd = datetime.FromUnix(dates[len(dates)-1])
+		}
+		cal.EPSEstimate = events.Earnings.EarningsAverage
+	}
+
+	if earnings := summary.Earnings; earnings != nil {
+		cal.History = earnings.EarningsChart.Quarterly
+		if cal.EPSEstimate == nil {
+			cal.EPSEstimate = earnings.EarningsChart.CurrentQuarterEstimate
+		}
+	}
+
+	return cal, nil
+}
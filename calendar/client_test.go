@@ -0,0 +1,63 @@
+package calendar
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient returns a client for a server
+// responding with the given quote summary result.
+func newTestClient(t *testing.T, result string) Client {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"quoteSummary":{"result":[%s]}}`, result)
+	}))
+	return Client{B: backend}
+}
+
+func TestGetEarnings(t *testing.T) {
+	c := newTestClient(t, `{
+		"calendarEvents":{"earnings":{
+			"earningsDate":[{"raw":1714075200,"fmt":"2024-04-25"},{"raw":1714420800,"fmt":"2024-04-29"}],
+			"earningsAverage":{"raw":1.5,"fmt":"1.50"}
+		}},
+		"earnings":{"earningsChart":{"quarterly":[
+			{"date":"3Q2023","actual":{"raw":1.46},"estimate":{"raw":1.39}},
+			{"date":"4Q2023","actual":{"raw":2.18},"estimate":{"raw":2.1}}
+		],"currentQuarterEstimate":{"raw":1.52}}}
+	}`)
+
+	cal, err := c.GetEarningsP(&Params{Symbol: "AAPL"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, cal)
+	assert.Equal(t, "AAPL", cal.Symbol)
+	assert.Equal(t, 1714075200, cal.EarningsStart.Unix())
+	assert.Equal(t, 1714420800, cal.EarningsEnd.Unix())
+	assert.Equal(t, 1.5, *cal.EPSEstimate)
+	assert.Len(t, cal.History, 2)
+	assert.Equal(t, "3Q2023", cal.History[0].Quarter)
+	assert.Equal(t, 1.46, *cal.History[0].Actual)
+}
+
+func TestGetEarningsETF(t *testing.T) {
+	c := newTestClient(t, `{"calendarEvents":{"earnings":{"earningsDate":[],"earningsAverage":{}}}}`)
+
+	cal, err := c.GetEarningsP(&Params{Symbol: "SPY"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, cal)
+	assert.Nil(t, cal.EarningsStart)
+	assert.Nil(t, cal.EarningsEnd)
+	assert.Nil(t, cal.EPSEstimate)
+}
+
+func TestNilParamsEarnings(t *testing.T) {
+	cal, err := GetEarningsP(nil)
+
+	assert.Nil(t, cal)
+	assert.Equal(t, "code: api-error, detail: missing function argument", err.Error())
+}
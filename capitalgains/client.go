@@ -0,0 +1,90 @@
+package capitalgains
+
+import (
+	"sort"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/chart"
+	"github.com/fijoyapp/finance-go/datetime"
+	form "github.com/fijoyapp/finance-go/form"
+	"github.com/fijoyapp/finance-go/iter"
+)
+
+// Client is used to invoke capital gains APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and capital gains history information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Symbol string `form:"-"`
+	// Start and End bound the capital gains history.
+	// The full history is requested if Start is nil.
+	Start *datetime.Datetime `form:"-"`
+	End   *datetime.Datetime `form:"-"`
+}
+
+// Iter is a structure containing results
+// and related metadata for a
+// yfin capital gains history request.
+type Iter struct {
+	*iter.Iter
+}
+
+// CapitalGain returns the next CapitalGain
+// visited by a call to Next.
+func (i *Iter) CapitalGain() *finance.CapitalGain {
+	return i.Current().(*finance.CapitalGain)
+}
+
+// Get returns a capital gains distribution history in chronological order
+// and requires a params struct as an argument.
+func Get(params *Params) *Iter {
+	return getC().Get(params)
+}
+
+// Get returns a capital gains distribution history in chronological order.
+// Symbols without distributions, such as non-fund symbols, yield an empty iterator.
+func (c Client) Get(params *Params) *Iter {
+
+	if params == nil || len(params.Symbol) == 0 {
+		return &Iter{iter.NewE(finance.CreateArgumentError())}
+	}
+
+	return &Iter{iter.New(nil, func(b *form.Values) (interface{}, []interface{}, error) {
+
+		events, err := chart.Client{B: c.B}.GetEvents(&chart.EventsParams{
+			Params: params.Params,
+			Symbol: params.Symbol,
+			Events: "capitalGains",
+			Start:  params.Start,
+			End:    params.End,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		gains := make([]*finance.CapitalGain, 0, len(events.CapitalGains))
+		for _, g := range events.CapitalGains {
+			gains = append(gains, g)
+		}
+		sort.Slice(gains, func(i, j int) bool {
+			return gains[i].Date.Unix() < gains[j].Date.Unix()
+		})
+
+		values := make([]interface{}, len(gains))
+		for i, g := range gains {
+			values[i] = g
+		}
+
+		return nil, values, nil
+	})}
+}
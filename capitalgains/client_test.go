@@ -0,0 +1,53 @@
+package capitalgains
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient returns a client for a server
+// responding with the given chart events.
+func newTestClient(t *testing.T, events string) Client {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v8/finance/chart/INPSX", r.URL.Path)
+		assert.Equal(t, "capitalGains", r.URL.Query().Get("events"))
+		w.Write([]byte(`{"chart":{"result":[{"events":` + events + `}],"error":null}}`))
+	}))
+	return Client{B: backend}
+}
+
+func TestGetCapitalGains(t *testing.T) {
+	c := newTestClient(t, `{"capitalGains":{
+		"1702650600":{"amount":1.25,"date":1702650600},
+		"1671201000":{"amount":0.87,"date":1671201000}
+	}}`)
+
+	iter := c.Get(&Params{Symbol: "INPSX"})
+
+	assert.True(t, iter.Next())
+	assert.Equal(t, 0.87, iter.CapitalGain().Amount)
+	assert.Equal(t, 1671201000, iter.CapitalGain().Date.Unix())
+	assert.True(t, iter.Next())
+	assert.Equal(t, 1.25, iter.CapitalGain().Amount)
+	assert.False(t, iter.Next())
+	assert.Nil(t, iter.Err())
+}
+
+func TestGetNoCapitalGains(t *testing.T) {
+	c := newTestClient(t, `null`)
+
+	iter := c.Get(&Params{Symbol: "INPSX"})
+
+	assert.False(t, iter.Next())
+	assert.Nil(t, iter.Err())
+}
+
+func TestNilParamsCapitalGains(t *testing.T) {
+	iter := Get(nil)
+
+	assert.False(t, iter.Next())
+	assert.Equal(t, "code: api-error, detail: missing function argument", iter.Err().Error())
+}
@@ -2,6 +2,7 @@ package chart
 
 import (
 	"context"
+	"time"
 
 	finance "github.com/fijoyapp/finance-go"
 	"github.com/fijoyapp/finance-go/datetime"
@@ -30,19 +31,57 @@ type Params struct {
 	End      *datetime.Datetime `form:"-"`
 	Interval datetime.Interval  `form:"-"`
 
+	// Period1 and Period2 bound the chart in unix seconds. When set,
+	// they take precedence over Start and End and are sent verbatim,
+	// without any timezone conversion.
+	Period1 int64 `form:"-"`
+	Period2 int64 `form:"-"`
+
+	// Range is a shorthand such as "1mo" or "max" relative to now.
+	// When set, it takes precedence over Start, End, Period1 and Period2.
+	Range string `form:"-"`
+
+	// IncludeExt includes pre and post market
+	// bars in intraday charts. Defaults to false.
 	IncludeExt bool `form:"includePrePost"`
 
+	// Events requests corporate events alongside the bars, such
+	// as "div|split". They are returned by Iter.Events.
+	Events string `form:"events"`
+
+	// Concurrency bounds the number of requests
+	// made at once by List. Defaults to DefaultConcurrency.
+	Concurrency int `form:"-"`
+
 	// Internal request fields.
 	interval string `form:"interval"`
+	rng      string `form:"range"`
 	start    int    `form:"period1"`
 	end      int    `form:"period2"`
 }
 
+// validRanges are the range shorthands
+// accepted by the chart endpoint.
+var validRanges = map[string]bool{
+	string(datetime.OneDay):     true,
+	string(datetime.FiveDay):    true,
+	string(datetime.OneMonth):   true,
+	string(datetime.ThreeMonth): true,
+	string(datetime.SixMonth):   true,
+	string(datetime.OneYear):    true,
+	string(datetime.TwoYear):    true,
+	string(datetime.FiveYear):   true,
+	string(datetime.TenYear):    true,
+	string(datetime.YTD):        true,
+	string(datetime.Max):        true,
+}
+
 // Iter is a structure containing results
 // and related metadata for a
 // yfin chart request.
 type Iter struct {
 	*iter.Iter
+	events *finance.ChartEvents
 }
 
 // Bar returns the next Bar
@@ -51,10 +90,18 @@ func (i *Iter) Bar() *finance.ChartBar {
 	return i.Current().(*finance.ChartBar)
 }
 
-// Meta returns the chart metadata
-// related to a chart response.
-func (i *Iter) Meta() finance.ChartMeta {
-	return i.Iter.Meta().(finance.ChartMeta)
+// Meta returns the chart metadata, such as the exchange,
+// timezone and currency, related to a chart response.
+// It is nil if the request failed.
+func (i *Iter) Meta() *finance.ChartMeta {
+	m, _ := i.Iter.Meta().(*finance.ChartMeta)
+	return m
+}
+
+// Events returns the corporate events requested with
+// Params.Events. It is nil if the chart has none.
+func (i *Iter) Events() *finance.ChartEvents {
+	return i.events
 }
 
 // Get returns a historical chart.
@@ -70,7 +117,7 @@ func (c Client) Get(params *Params) *Iter {
 	// Construct request from params input.
 	// TODO: validate symbol..
 	if params == nil || len(params.Symbol) == 0 {
-		return &Iter{iter.NewE(finance.CreateArgumentError())}
+		return &Iter{Iter: iter.NewE(finance.CreateArgumentError())}
 	}
 
 	if params.Context == nil {
@@ -78,17 +125,33 @@ func (c Client) Get(params *Params) *Iter {
 		params.Context = &ctx
 	}
 
-	// Start and End times
+	// Start and End times, superseded by Range.
+	params.rng = ""
 	params.start = -1
 	params.end = -1
-	if params.Start != nil {
-		params.start = params.Start.Unix()
-	}
-	if params.End != nil {
-		params.end = params.End.Unix()
-	}
-	if params.start > params.end {
-		return &Iter{iter.NewE(finance.CreateChartTimeError())}
+	if params.Range != "" {
+		if !validRanges[params.Range] {
+			return &Iter{Iter: iter.NewE(finance.CreateChartRangeError(params.Range))}
+		}
+		params.rng = params.Range
+		params.start = 0
+		params.end = 0
+	} else {
+		if params.Start != nil {
+			params.start = params.Start.Unix()
+		}
+		if params.End != nil {
+			params.end = params.End.Unix()
+		}
+		if params.Period1 != 0 {
+			params.start = int(params.Period1)
+		}
+		if params.Period2 != 0 {
+			params.end = int(params.Period2)
+		}
+		if params.start > params.end {
+			return &Iter{Iter: iter.NewE(finance.CreateChartTimeError())}
+		}
 	}
 
 	// Parse interval.
@@ -103,7 +166,8 @@ func (c Client) Get(params *Params) *Iter {
 	body.Set("region", "US")
 	body.Set("corsDomain", "com.finance.yahoo")
 
-	return &Iter{iter.New(body, func(b *form.Values) (m interface{}, bars []interface{}, err error) {
+	it := &Iter{}
+	it.Iter = iter.New(body, func(b *form.Values) (m interface{}, bars []interface{}, err error) {
 
 		resp := response{}
 		err = c.B.Call("v8/finance/chart/"+params.Symbol, body, params.Context, &resp)
@@ -116,6 +180,11 @@ func (c Client) Get(params *Params) *Iter {
 			return
 		}
 
+		if len(resp.Inner.Results) == 0 {
+			err = finance.CreateRemoteErrorS("no results in chart response")
+			return
+		}
+
 		result := resp.Inner.Results[0]
 		if result == nil || result.Indicators == nil {
 			err = finance.CreateRemoteErrorS("no results in chart response")
@@ -131,10 +200,12 @@ func (c Client) Get(params *Params) *Iter {
 
 		// Process chart response
 		// and chart meta data.
+		loc := result.Meta.Location()
 		for i, t := range result.Timestamp {
 
 			b := &finance.ChartBar{
 				Timestamp: t,
+				Time:      time.Unix(int64(t), 0).In(loc),
 				Open:      decimal.NewFromFloat(barQuotes[0].Open[i]),
 				High:      decimal.NewFromFloat(barQuotes[0].High[i]),
 				Low:       decimal.NewFromFloat(barQuotes[0].Low[i]),
@@ -142,15 +213,19 @@ func (c Client) Get(params *Params) *Iter {
 				Volume:    barQuotes[0].Volume[i],
 			}
 
-			if adjCloses != nil && adjCloses[0] != nil {
+			// Adjusted closes are omitted for intraday
+			// intervals, leaving AdjClose zero.
+			if len(adjCloses) > 0 && adjCloses[0] != nil && i < len(adjCloses[0].Adjclose) {
 				b.AdjClose = decimal.NewFromFloat(adjCloses[0].Adjclose[i])
 			}
 
 			bars = append(bars, b)
 		}
 
-		return result.Meta, bars, nil
-	})}
+		it.events = result.Events
+		return &result.Meta, bars, nil
+	})
+	return it
 }
 
 // response is a yfin chart response.
@@ -163,8 +238,9 @@ type response struct {
 
 // result is an umbrella object for chart results.
 type result struct {
-	Meta       finance.ChartMeta `json:"meta"`
-	Timestamp  []int             `json:"timestamp"`
+	Meta       finance.ChartMeta    `json:"meta"`
+	Timestamp  []int                `json:"timestamp"`
+	Events     *finance.ChartEvents `json:"events"`
 	Indicators *struct {
 		Quote []*struct {
 			Open   []float64 `json:"open"`
@@ -1,9 +1,22 @@
 package chart
 
 import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/datetime"
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/fijoyapp/finance-go/form"
+	"github.com/fijoyapp/finance-go/iter"
 	tests "github.com/fijoyapp/finance-go/testing"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -55,3 +68,436 @@ func TestBadSymbolChart(t *testing.T) {
 	assert.False(t, chart.Next())
 	assert.NotNil(t, chart.Err())
 }
+
+// newTestClient returns a client for a server
+// responding with the given chart result.
+func newTestClient(t *testing.T, result string) Client {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"chart":{"result":[%s],"error":null}}`, result)
+	}))
+	return Client{B: backend}
+}
+
+func TestGetChartAdjClose(t *testing.T) {
+	c := newTestClient(t, `{
+		"meta":{"symbol":"AAPL","dataGranularity":"1d"},
+		"timestamp":[1515681000],
+		"indicators":{
+			"quote":[{"open":[174.5],"high":[175.5],"low":[174.5],"close":[175.3],"volume":[18000000]}],
+			"adjclose":[{"adjclose":[166.9]}]
+		}
+	}`)
+
+	iter := c.Get(&Params{Symbol: "AAPL", Interval: datetime.OneDay})
+
+	assert.True(t, iter.Next())
+	assert.Equal(t, "166.9", iter.Bar().AdjClose.String())
+	assert.Nil(t, iter.Err())
+}
+
+func TestGetChartIntradayAdjClose(t *testing.T) {
+	c := newTestClient(t, `{
+		"meta":{"symbol":"AAPL","dataGranularity":"5m"},
+		"timestamp":[1515681000,1515681300],
+		"indicators":{
+			"quote":[{"open":[174.5,175],"high":[175,175.2],"low":[174.5,174.9],"close":[175,175.1],"volume":[100,200]}],
+			"adjclose":[{"adjclose":[]}]
+		}
+	}`)
+
+	iter := c.Get(&Params{Symbol: "AAPL", Interval: datetime.FiveMins})
+
+	assert.True(t, iter.Next())
+	assert.True(t, iter.Bar().AdjClose.IsZero())
+	assert.True(t, iter.Next())
+	assert.True(t, iter.Bar().AdjClose.IsZero())
+	assert.False(t, iter.Next())
+	assert.Nil(t, iter.Err())
+}
+
+func TestGetChartIncludeExt(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("includePrePost"))
+		// 2018-01-11 04:00 and 09:30 ET.
+		w.Write([]byte(`{"chart":{"result":[{
+			"meta":{"symbol":"AAPL","dataGranularity":"1m"},
+			"timestamp":[1515661200,1515681000],
+			"indicators":{"quote":[{"open":[174,174.5],"high":[174,175],"low":[174,174.5],"close":[174,175],"volume":[10,20]}]}
+		}],"error":null}}`))
+	}))
+	c := Client{B: backend}
+
+	iter := c.Get(&Params{Symbol: "AAPL", Interval: datetime.OneMin, IncludeExt: true})
+
+	assert.True(t, iter.Next())
+	assert.Equal(t, 1515661200, iter.Bar().Timestamp)
+	assert.True(t, iter.Next())
+	assert.Nil(t, iter.Err())
+}
+
+func TestGetChartEvents(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "div|split", r.URL.Query().Get("events"))
+		w.Write([]byte(`{"chart":{"result":[{
+			"meta":{"symbol":"AAPL","dataGranularity":"1d"},
+			"timestamp":[1515681000],
+			"events":{"dividends":{"1515681000":{"amount":0.63,"date":1515681000}}},
+			"indicators":{"quote":[{"open":[174.5],"high":[175.5],"low":[174.5],"close":[175.3],"volume":[18000000]}]}
+		}],"error":null}}`))
+	}))
+	c := Client{B: backend}
+
+	iter := c.Get(&Params{Symbol: "AAPL", Interval: datetime.OneDay, Events: "div|split"})
+
+	assert.Nil(t, iter.Err())
+	assert.Len(t, iter.Events().Dividends, 1)
+	assert.Equal(t, 0.63, iter.Events().Dividends["1515681000"].Amount)
+}
+
+func TestGetEvents(t *testing.T) {
+	var query url.Values
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`{"chart":{"result":[{"events":{"splits":{
+			"1598880600":{"numerator":4,"denominator":1,"splitRatio":"4:1","date":1598880600}
+		}}}],"error":null}}`))
+	}))
+	c := Client{B: backend}
+
+	events, err := c.GetEvents(&EventsParams{Symbol: "AAPL", Events: "split"})
+	assert.Nil(t, err)
+	assert.Equal(t, "4:1", events.Splits["1598880600"].Ratio)
+	assert.Equal(t, "split", query.Get("events"))
+	assert.Equal(t, "1d", query.Get("interval"))
+	assert.Equal(t, "max", query.Get("range"))
+
+	_, err = c.GetEvents(&EventsParams{Symbol: "AAPL"})
+	assert.Nil(t, err)
+
+	_, err = c.GetEvents(nil)
+	assert.EqualError(t, err, "code: api-error, detail: missing function argument")
+}
+
+func TestGetChartPeriod(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1515542400", r.URL.Query().Get("period1"))
+		assert.Equal(t, "1515715200", r.URL.Query().Get("period2"))
+		w.Write([]byte(`{"chart":{"result":[{
+			"meta":{"symbol":"AAPL","dataGranularity":"1d"},
+			"timestamp":[1515594600],
+			"indicators":{"quote":[{"open":[173.2],"high":[175.4],"low":[173],"close":[174.3],"volume":[23000000]}]}
+		}],"error":null}}`))
+	}))
+	c := Client{B: backend}
+
+	p := &Params{
+		Symbol:   "AAPL",
+		Interval: datetime.OneDay,
+		Period1:  1515542400,
+		Period2:  1515715200,
+	}
+	iter := c.Get(p)
+	assert.True(t, iter.Next())
+	assert.Nil(t, iter.Err())
+}
+
+func TestBadPeriodChart(t *testing.T) {
+	p := &Params{
+		Symbol:  tests.TestEquitySymbol,
+		Period1: 1515715200,
+		Period2: 1515542400,
+	}
+	iter := Get(p)
+	assert.False(t, iter.Next())
+	assert.NotNil(t, iter.Err())
+}
+
+func TestGetChartRange(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "1y", q.Get("range"))
+		assert.Empty(t, q.Get("period1"))
+		assert.Empty(t, q.Get("period2"))
+		w.Write([]byte(`{"chart":{"result":[{
+			"meta":{"symbol":"AAPL","dataGranularity":"1d","range":"1y"},
+			"timestamp":[1515594600],
+			"indicators":{"quote":[{"open":[173.2],"high":[175.4],"low":[173],"close":[174.3],"volume":[23000000]}]}
+		}],"error":null}}`))
+	}))
+	c := Client{B: backend}
+
+	p := &Params{
+		Symbol:   "AAPL",
+		Interval: datetime.OneDay,
+		Range:    string(datetime.OneYear),
+	}
+	iter := c.Get(p)
+	assert.True(t, iter.Next())
+	assert.Nil(t, iter.Err())
+}
+
+func TestBadRangeChart(t *testing.T) {
+	p := &Params{Symbol: tests.TestEquitySymbol, Range: "7w"}
+	iter := Get(p)
+	assert.False(t, iter.Next())
+	assert.EqualError(t, iter.Err(), `code: api-error, detail: unsupported chart range "7w"`)
+}
+
+func TestListChart(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		symbol := strings.TrimPrefix(r.URL.Path, "/v8/finance/chart/")
+		if symbol == "BADSYMBOL" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"chart":{"result":null,"error":{"code":"Not Found","description":"No data found, symbol may be delisted"}}}`))
+			return
+		}
+		fmt.Fprintf(w, `{"chart":{"result":[{
+			"meta":{"symbol":%q,"dataGranularity":"1d"},
+			"timestamp":[1515594600],
+			"indicators":{"quote":[{"open":[173.2],"high":[175.4],"low":[173],"close":[174.3],"volume":[23000000]}]}
+		}],"error":null}}`, symbol)
+	}))
+	c := Client{B: backend}
+
+	symbols := []string{"AAPL", "SPY", "BADSYMBOL"}
+	charts, err := c.List(symbols, &Params{Concurrency: 2})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.(finance.ListError), "BADSYMBOL")
+	assert.Len(t, charts, 2)
+	assert.True(t, charts["AAPL"].Next())
+	assert.Equal(t, "SPY", charts["SPY"].Meta().Symbol)
+}
+
+func TestGetChartMeta(t *testing.T) {
+	c := newTestClient(t, `{
+		"meta":{"currency":"USD","symbol":"AAPL","exchangeName":"NMS","timezone":"EST","dataGranularity":"1d"},
+		"timestamp":[1515594600],
+		"indicators":{"quote":[{"open":[173.2],"high":[175.4],"low":[173],"close":[174.3],"volume":[23000000]}]}
+	}`)
+
+	iter := c.Get(&Params{Symbol: "AAPL"})
+	assert.True(t, iter.Next())
+	meta := iter.Meta()
+	assert.NotNil(t, meta)
+	assert.Equal(t, "AAPL", meta.Symbol)
+	assert.Equal(t, "USD", meta.Currency)
+	assert.Equal(t, "NMS", meta.ExchangeName)
+	assert.Equal(t, "EST", meta.Timezone)
+}
+
+func TestBadSymbolChartMeta(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"chart":{"result":null,"error":{"code":"Not Found","description":"No data found, symbol may be delisted"}}}`))
+	}))
+	c := Client{B: backend}
+
+	iter := c.Get(&Params{Symbol: "BADSYMBOL"})
+	assert.False(t, iter.Next())
+	assert.Nil(t, iter.Meta())
+}
+
+func TestGetChartBarTime(t *testing.T) {
+	c := newTestClient(t, `{
+		"meta":{"symbol":"AAPL","exchangeTimezoneName":"America/New_York","dataGranularity":"1d"},
+		"timestamp":[1515594600],
+		"indicators":{"quote":[{"open":[173.2],"high":[175.4],"low":[173],"close":[174.3],"volume":[23000000]}]}
+	}`)
+
+	iter := c.Get(&Params{Symbol: "AAPL"})
+	assert.True(t, iter.Next())
+	bar := iter.Bar()
+	assert.Equal(t, int64(bar.Timestamp), bar.Time.Unix())
+	assert.Equal(t, "America/New_York", bar.Time.Location().String())
+	assert.Equal(t, 9, bar.Time.Hour())
+}
+
+func TestWriteCSV(t *testing.T) {
+	loc := time.FixedZone("GMT", 0)
+	bar := &finance.ChartBar{
+		Open:      decimal.NewFromFloat(1.5),
+		High:      decimal.NewFromFloat(2),
+		Low:       decimal.NewFromFloat(1),
+		Close:     decimal.NewFromFloat(1.75),
+		AdjClose:  decimal.NewFromFloat(1.7),
+		Volume:    100,
+		Timestamp: 1515542400,
+		Time:      time.Unix(1515542400, 0).In(loc),
+	}
+	i := &Iter{Iter: iter.New(nil, func(*form.Values) (interface{}, []interface{}, error) {
+		return nil, []interface{}{bar}, nil
+	})}
+
+	buf := &bytes.Buffer{}
+	assert.Nil(t, WriteCSV(buf, i))
+	assert.Equal(t, "date,open,high,low,close,adjclose,volume\n2018-01-10T00:00:00Z,1.5,2,1,1.75,1.7,100\n", buf.String())
+}
+
+func TestWriteCSVError(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := WriteCSV(buf, Get(nil))
+	assert.EqualError(t, err, "code: api-error, detail: missing function argument")
+	assert.Empty(t, buf.String())
+}
+
+func TestGetReturn(t *testing.T) {
+	day := func(d int) int64 { return time.Date(2020, 1, d, 14, 30, 0, 0, time.UTC).Unix() }
+	var query url.Values
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprintf(w, `{"chart":{"result":[{
+			"meta":{"firstTradeDate":%d,"exchangeTimezoneName":"America/New_York"},
+			"timestamp":[%d,%d,%d,%d],
+			"indicators":{
+				"quote":[{"open":[1,1,1,1],"high":[1,1,1,1],"low":[1,1,1,1],"close":[1,1,1,1],"volume":[1,1,1,1]}],
+				"adjclose":[{"adjclose":[100,0,105,110]}]
+			}
+		}]}}`, day(6), day(6), day(7), day(8), day(9))
+	}))
+	c := Client{B: backend}
+
+	r, err := c.GetReturn(&Params{
+		Symbol: "NEW",
+		Start:  &datetime.Datetime{Year: 2019, Month: 1, Day: 1},
+		End:    &datetime.Datetime{Year: 2020, Month: 1, Day: 8},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "1d", query.Get("interval"))
+	assert.Equal(t, strconv.Itoa((&datetime.Datetime{Year: 2020, Month: 1, Day: 9}).Unix()), query.Get("period2"))
+	assert.InDelta(t, 5, r.Percent, 1e-9)
+	assert.Equal(t, int(day(6)), r.Start.Timestamp)
+	assert.Equal(t, int(day(8)), r.End.Timestamp)
+	assert.True(t, r.Clamped)
+
+	_, err = c.GetReturn(&Params{Symbol: "NEW"})
+	assert.EqualError(t, err, "code: api-error, detail: missing function argument")
+}
+
+func TestResample(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+	open := time.Date(2024, 6, 3, 9, 30, 0, 0, ny)
+	now := open.Add(5*time.Minute + 30*time.Second)
+
+	newIter := func() *Iter {
+		bars := []interface{}{}
+		for i, close := range []int64{10, 12, 0, 11, 13} {
+			ts := open.Add(time.Duration(i) * time.Minute)
+			bars = append(bars, &finance.ChartBar{
+				Open:      decimal.NewFromInt(close - 1),
+				High:      decimal.NewFromInt(close + int64(i)),
+				Low:       decimal.NewFromInt(close - 2),
+				Close:     decimal.NewFromInt(close),
+				Volume:    100 * (i + 1),
+				Timestamp: int(ts.Unix()),
+				Time:      ts,
+			})
+		}
+		return &Iter{Iter: iter.New(nil, func(*form.Values) (interface{}, []interface{}, error) {
+			return &finance.ChartMeta{DataGranularity: "1m"}, bars, nil
+		})}
+	}
+
+	bars, err := resample(newIter(), 2*time.Minute, now)
+	assert.Nil(t, err)
+	assert.Len(t, bars, 3)
+
+	assert.Equal(t, int(open.Unix()), bars[0].Timestamp)
+	assert.Equal(t, "9", bars[0].Open.String())
+	assert.Equal(t, "13", bars[0].High.String())
+	assert.Equal(t, "8", bars[0].Low.String())
+	assert.Equal(t, "12", bars[0].Close.String())
+	assert.Equal(t, 300, bars[0].Volume)
+	assert.False(t, bars[0].Incomplete)
+
+	// The missing bar at 9:32 is skipped.
+	assert.Equal(t, open.Add(2*time.Minute), bars[1].Time)
+	assert.Equal(t, "11", bars[1].Close.String())
+	assert.Equal(t, 400, bars[1].Volume)
+	assert.False(t, bars[1].Incomplete)
+
+	assert.Equal(t, "13", bars[2].Close.String())
+	assert.Equal(t, 500, bars[2].Volume)
+	assert.True(t, bars[2].Incomplete)
+
+	bars, err = resample(newIter(), 30*time.Minute, now)
+	assert.Nil(t, err)
+	assert.Len(t, bars, 1)
+	assert.Equal(t, "17", bars[0].High.String())
+	assert.True(t, bars[0].Incomplete)
+
+	// Buckets of finished charts are complete.
+	bars, err = resample(newIter(), 30*time.Minute, open.Add(time.Hour))
+	assert.Nil(t, err)
+	assert.False(t, bars[0].Incomplete)
+
+	_, err = Resample(newIter(), 90*time.Second)
+	assert.EqualError(t, err, "chart: cannot resample 1m0s bars to 1m30s")
+}
+
+func TestResampleSessions(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+
+	newIter := func(meta *finance.ChartMeta, times ...time.Time) *Iter {
+		bars := []interface{}{}
+		for _, ts := range times {
+			bars = append(bars, &finance.ChartBar{
+				Open:      decimal.NewFromInt(1),
+				High:      decimal.NewFromInt(1),
+				Low:       decimal.NewFromInt(1),
+				Close:     decimal.NewFromInt(1),
+				Volume:    1,
+				Timestamp: int(ts.Unix()),
+				Time:      ts,
+			})
+		}
+		return &Iter{Iter: iter.New(nil, func(*form.Values) (interface{}, []interface{}, error) {
+			return meta, bars, nil
+		})}
+	}
+
+	monday := time.Date(2024, 6, 3, 9, 30, 0, 0, ny)
+	tuesday := monday.AddDate(0, 0, 1)
+	meta := &finance.ChartMeta{DataGranularity: "30m"}
+	meta.CurrentTradingPeriod.Pre.Start = int(tuesday.Add(-5*time.Hour - 30*time.Minute).Unix())
+	meta.CurrentTradingPeriod.Regular.Start = int(tuesday.Unix())
+	meta.CurrentTradingPeriod.Regular.End = int(tuesday.Add(6*time.Hour + 30*time.Minute).Unix())
+
+	// Hourly buckets start at the open rather than on the hour,
+	// and premarket bars are counted back from it.
+	bars, err := resample(newIter(meta,
+		monday.Add(-30*time.Minute),
+		monday, monday.Add(30*time.Minute), monday.Add(time.Hour),
+		tuesday, tuesday.Add(30*time.Minute),
+	), time.Hour, tuesday.Add(time.Hour+15*time.Minute))
+	assert.Nil(t, err)
+	assert.Len(t, bars, 4)
+	assert.Equal(t, monday.Add(-time.Hour), bars[0].Time)
+	assert.Equal(t, monday, bars[1].Time)
+	assert.Equal(t, 2, bars[1].Volume)
+	assert.Equal(t, monday.Add(time.Hour), bars[2].Time)
+	assert.False(t, bars[2].Incomplete)
+	assert.Equal(t, tuesday, bars[3].Time)
+	assert.False(t, bars[3].Incomplete)
+
+	// The bucket of a finished session is complete,
+	// even if its end hasn't passed.
+	last := tuesday.Add(6 * time.Hour)
+	bars, err = resample(newIter(meta, last), 4*time.Hour, last.Add(time.Hour))
+	assert.Nil(t, err)
+	assert.False(t, bars[0].Incomplete)
+
+	// Without trading periods, buckets start
+	// with the first bar of each session.
+	bars, err = resample(newIter(&finance.ChartMeta{DataGranularity: "30m"},
+		monday, monday.Add(30*time.Minute), monday.Add(time.Hour),
+		tuesday.Add(30*time.Minute),
+	), time.Hour, tuesday.Add(time.Hour))
+	assert.Nil(t, err)
+	assert.Len(t, bars, 3)
+	assert.Equal(t, monday, bars[0].Time)
+	assert.Equal(t, monday.Add(time.Hour), bars[1].Time)
+	assert.Equal(t, tuesday.Add(30*time.Minute), bars[2].Time)
+	assert.True(t, bars[2].Incomplete)
+}
@@ -0,0 +1,53 @@
+package chart
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	finance "github.com/fijoyapp/finance-go"
+)
+
+// csvHeader is the header row written by WriteCSV.
+var csvHeader = []string{"date", "open", "high", "low", "close", "adjclose", "volume"}
+
+// WriteCSV writes the bars of a chart as CSV, with a header row and
+// dates formatted as RFC3339 in the exchange timezone. It returns
+// the iterator error, if any, before writing anything.
+func WriteCSV(w io.Writer, iter *Iter) error {
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for iter.Next() {
+		if err := cw.Write(BarRecord(iter.Bar(), time.RFC3339)); err != nil {
+			return err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// BarRecord returns the CSV record of a bar, in the column order of
+// WriteCSV, with its time formatted with layout.
+func BarRecord(b *finance.ChartBar, layout string) []string {
+	return []string{
+		b.Time.Format(layout),
+		b.Open.String(),
+		b.High.String(),
+		b.Low.String(),
+		b.Close.String(),
+		b.AdjClose.String(),
+		strconv.Itoa(b.Volume),
+	}
+}
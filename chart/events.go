@@ -0,0 +1,105 @@
+package chart
+
+import (
+	"context"
+	"time"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/datetime"
+	form "github.com/fijoyapp/finance-go/form"
+)
+
+// EventsParams carries a context and corporate events information.
+type EventsParams struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Symbol string `form:"-"`
+	// Events selects the events, such as "div", "split" or "capitalGains".
+	Events string `form:"-"`
+	// Start and End bound the events.
+	// The full history is requested if Start is nil.
+	Start *datetime.Datetime `form:"-"`
+	End   *datetime.Datetime `form:"-"`
+
+	// Internal request fields.
+	interval string `form:"interval"`
+	events   string `form:"events"`
+	rng      string `form:"range"`
+	start    int    `form:"period1"`
+	end      int    `form:"period2"`
+}
+
+// GetEvents returns the corporate events of a symbol
+// and requires a params struct as an argument.
+func GetEvents(params *EventsParams) (*finance.ChartEvents, error) {
+	return getC().GetEvents(params)
+}
+
+// GetEvents returns the corporate events of a symbol, from a daily
+// chart request that asks for them. Symbols without any of the events
+// yield empty events rather than an error.
+func (c Client) GetEvents(params *EventsParams) (*finance.ChartEvents, error) {
+
+	if params == nil || len(params.Symbol) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	if params.Context == nil {
+		ctx := context.TODO()
+		params.Context = &ctx
+	}
+
+	params.interval = string(datetime.OneDay)
+	params.events = params.Events
+	params.rng = ""
+	params.start = 0
+	params.end = 0
+	if params.Start == nil {
+		params.rng = string(datetime.Max)
+	} else {
+		params.start = params.Start.Unix()
+		params.end = int(time.Now().Unix())
+		if params.End != nil {
+			params.end = params.End.Unix()
+		}
+		if params.start > params.end {
+			return nil, finance.CreateChartTimeError()
+		}
+	}
+
+	body := &form.Values{}
+	form.AppendTo(body, params)
+
+	resp := eventsResponse{}
+	err := c.B.Call("v8/finance/chart/"+params.Symbol, body, params.Context, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Inner.Error != nil {
+		return nil, resp.Inner.Error
+	}
+
+	if len(resp.Inner.Results) == 0 || resp.Inner.Results[0] == nil {
+		return nil, finance.CreateRemoteErrorS("no results in chart response")
+	}
+
+	events := resp.Inner.Results[0].Events
+	if events == nil {
+		events = &finance.ChartEvents{}
+	}
+	return events, nil
+}
+
+// eventsResponse is a yfin chart response
+// of which only the events are decoded.
+type eventsResponse struct {
+	Inner struct {
+		Results []*struct {
+			Events *finance.ChartEvents `json:"events"`
+		} `json:"result"`
+		Error *finance.YfinError `json:"error"`
+	} `json:"chart"`
+}
@@ -0,0 +1,44 @@
+package chart
+
+import (
+	finance "github.com/fijoyapp/finance-go"
+)
+
+// DefaultConcurrency is the number of
+// concurrent requests made by List.
+const DefaultConcurrency = 5
+
+// List returns historical charts for many symbols
+// fetched concurrently, keyed by symbol.
+func List(symbols []string, params *Params) (map[string]*Iter, error) {
+	return getC().List(symbols, params)
+}
+
+// List returns historical charts for many symbols fetched concurrently.
+// The Symbol field of params is ignored. Symbols that fail are left out
+// of the returned map and reported together in a finance.ListError.
+func (c Client) List(symbols []string, params *Params) (map[string]*Iter, error) {
+
+	if params == nil {
+		params = &Params{}
+	}
+
+	workers := params.Concurrency
+	if workers <= 0 {
+		workers = DefaultConcurrency
+	}
+
+	results, failed := finance.FetchConcurrently(symbols, workers, func(symbol string) (*Iter, error) {
+		// Get mutates its params, so each
+		// request works on its own copy.
+		p := *params
+		p.Symbol = symbol
+		it := c.Get(&p)
+		return it, it.Err()
+	})
+
+	if len(failed) > 0 {
+		return results, finance.ListError(failed)
+	}
+	return results, nil
+}
@@ -0,0 +1,159 @@
+package chart
+
+import (
+	"fmt"
+	"time"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/shopspring/decimal"
+)
+
+// Resample aggregates the bars of an intraday chart, such as 1m bars,
+// into bars of a longer interval, such as 2m or 30m, which yahoo doesn't
+// serve. Each bar takes the first open, highest high, lowest low, last
+// close and summed volume of its bucket. Buckets are aligned on the
+// regular market open of each day in the exchange timezone, or on the
+// first bar of each day if the chart has no trading periods, and are
+// timestamped with their start. Buckets without bars are skipped, and
+// a trailing bucket that is still running is included and marked
+// Incomplete. The interval must be a multiple of the chart interval of
+// at most a day.
+func Resample(iter *Iter, interval time.Duration) ([]finance.ChartBar, error) {
+	return resample(iter, interval, time.Now())
+}
+
+// resample resamples the bars of a chart as of now.
+func resample(iter *Iter, interval time.Duration, now time.Time) ([]finance.ChartBar, error) {
+	bars := []*finance.ChartBar{}
+	for iter.Next() {
+		// Missing bars are decoded as zeros.
+		if b := iter.Bar(); !b.Close.IsZero() {
+			bars = append(bars, b)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	meta := iter.Meta()
+	source := sourceInterval(meta, bars)
+	if interval <= 0 || interval > 24*time.Hour || (source > 0 && interval%source != 0) {
+		return nil, fmt.Errorf("chart: cannot resample %v bars to %v", source, interval)
+	}
+
+	resampled := []finance.ChartBar{}
+	var current *finance.ChartBar
+	var anchor, end time.Time
+	for _, b := range bars {
+		if open, ok := regularOpen(meta, b.Time); ok {
+			anchor = open
+		} else if anchor.IsZero() || !sameDate(anchor, b.Time) {
+			anchor = b.Time
+		}
+
+		// Bars before the open, such as premarket ones,
+		// fall in buckets counted back from it.
+		offset := b.Time.Sub(anchor)
+		steps := offset / interval
+		if offset < 0 && offset%interval != 0 {
+			steps--
+		}
+		start := anchor.Add(steps * interval)
+
+		if current == nil || !start.Equal(current.Time) {
+			if current != nil {
+				resampled = append(resampled, *current)
+			}
+
+			end = start.Add(interval)
+			current = &finance.ChartBar{
+				Open:      b.Open,
+				High:      b.High,
+				Low:       b.Low,
+				Timestamp: int(start.Unix()),
+				Time:      start,
+			}
+		}
+
+		current.High = decimal.Max(current.High, b.High)
+		current.Low = decimal.Min(current.Low, b.Low)
+		current.Close = b.Close
+		current.AdjClose = b.AdjClose
+		current.Volume += b.Volume
+	}
+
+	if current != nil {
+		last := bars[len(bars)-1]
+		current.Incomplete = last.Time.Add(source).Before(end) && running(meta, last, end, now)
+		resampled = append(resampled, *current)
+	}
+	return resampled, nil
+}
+
+// regularOpen returns the regular market open on the date of t, at
+// the time of day of the current trading period's open. It is false
+// if the chart has no trading periods.
+func regularOpen(meta *finance.ChartMeta, t time.Time) (time.Time, bool) {
+	if meta == nil || meta.CurrentTradingPeriod.Regular.Start == 0 {
+		return time.Time{}, false
+	}
+
+	open := time.Unix(int64(meta.CurrentTradingPeriod.Regular.Start), 0).In(t.Location())
+	y, m, d := t.Date()
+	return time.Date(y, m, d, open.Hour(), open.Minute(), open.Second(), 0, t.Location()), true
+}
+
+// running reports whether a bucket ending at end, whose last bar
+// is last, can still receive bars as of now. It can't once end has
+// passed, nor once the session of its last bar is over.
+func running(meta *finance.ChartMeta, last *finance.ChartBar, end, now time.Time) bool {
+	if !now.Before(end) {
+		return false
+	}
+	if meta == nil || meta.CurrentTradingPeriod.Regular.End == 0 {
+		return true
+	}
+
+	period := meta.CurrentTradingPeriod
+	start := period.Regular.Start
+	if period.Pre.Start > 0 {
+		start = period.Pre.Start
+	}
+	if last.Timestamp < start {
+		// The bar is from an earlier session.
+		return false
+	}
+
+	sessionEnd := period.Regular.End
+	if last.Timestamp >= period.Regular.End && period.Post.End > 0 {
+		sessionEnd = period.Post.End
+	}
+	return now.Unix() < int64(sessionEnd)
+}
+
+// sameDate reports whether a and b fall on the same day.
+func sameDate(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// sourceInterval returns the interval of the bars of a chart,
+// from its granularity if it's intraday, and otherwise from the
+// smallest gap between bars. It is 0 if neither is known.
+func sourceInterval(meta *finance.ChartMeta, bars []*finance.ChartBar) time.Duration {
+	if meta != nil {
+		if d, err := time.ParseDuration(meta.DataGranularity); err == nil && d > 0 {
+			return d
+		}
+	}
+
+	var source time.Duration
+	for i := 1; i < len(bars); i++ {
+		gap := time.Duration(bars[i].Timestamp-bars[i-1].Timestamp) * time.Second
+		if gap > 0 && (source == 0 || gap < source) {
+			source = gap
+		}
+	}
+	return source
+}
@@ -0,0 +1,93 @@
+package chart
+
+import (
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/datetime"
+	"github.com/shopspring/decimal"
+)
+
+// Return is the total return of a symbol between two daily bars.
+type Return struct {
+	// Percent is the total return in percent, including
+	// reinvested dividends, from the adjusted closes.
+	Percent float64
+	// Start and End are the bars the return is measured between.
+	Start, End *finance.ChartBar
+	// Clamped reports whether the requested start predates the
+	// first trade date of the symbol, in which case the return is
+	// measured from the first available bar instead.
+	Clamped bool
+}
+
+// TotalReturn returns the total return of a symbol in
+// percent between two dates, including reinvested dividends.
+func TotalReturn(symbol string, start, end datetime.Datetime) (float64, error) {
+	r, err := GetReturn(&Params{Symbol: symbol, Start: &start, End: &end})
+	if err != nil {
+		return 0, err
+	}
+	return r.Percent, nil
+}
+
+// GetReturn returns the total return of a symbol between
+// two dates and requires a params struct as an argument.
+func GetReturn(params *Params) (*Return, error) {
+	return getC().GetReturn(params)
+}
+
+// GetReturn returns the total return of a symbol between the daily
+// bars of the Start and End dates of params, measured on adjusted
+// closes so that dividends are reinvested. Both dates are included,
+// and the nearest trading days within them are used if they aren't
+// trading days themselves. Interval, Range and the periods are ignored.
+func (c Client) GetReturn(params *Params) (*Return, error) {
+	if params == nil || params.Start == nil || params.End == nil {
+		return nil, finance.CreateArgumentError()
+	}
+
+	p := *params
+	end := *params.End
+	p.End = end.AddDays(1)
+	p.Interval = datetime.OneDay
+	p.Range, p.Period1, p.Period2 = "", 0, 0
+
+	it := c.Get(&p)
+	r := &Return{}
+	for it.Next() {
+		bar := it.Bar()
+		if bar.AdjClose.IsZero() || afterDate(bar, &end) {
+			continue
+		}
+		if r.Start == nil {
+			r.Start = bar
+		}
+		r.End = bar
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	if r.Start == nil {
+		return nil, finance.CreateRemoteErrorS("no bars in chart range")
+	}
+
+	if meta := it.Meta(); meta != nil && meta.FirstTradeDate > params.Start.Unix() {
+		r.Clamped = true
+	}
+
+	change, _ := r.End.AdjClose.Div(r.Start.AdjClose).Sub(decimal.NewFromInt(1)).Float64()
+	r.Percent = change * 100
+	return r, nil
+}
+
+// afterDate reports whether a bar falls on a later date than d,
+// in the exchange timezone of the bar.
+func afterDate(bar *finance.ChartBar, d *datetime.Datetime) bool {
+	y, m, day := bar.Time.Date()
+	if y != d.Year {
+		return y > d.Year
+	}
+	if int(m) != d.Month {
+		return int(m) > d.Month
+	}
+	return day > d.Day
+}
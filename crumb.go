@@ -0,0 +1,185 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// yahooSessionURL is visited to obtain yahoo session cookies.
+	yahooSessionURL = "https://finance.yahoo.com/"
+	// yahooCrumbURL returns the crumb for the current session.
+	yahooCrumbURL = "https://query2.finance.yahoo.com/v1/test/getcrumb"
+)
+
+// crumbStore holds the yahoo crumb shared by all yfin requests.
+type crumbStore struct {
+	mu    sync.RWMutex
+	value string
+	fetch singleflight.Group
+}
+
+// yCrumb is the crumb used to authorize yfin requests.
+var yCrumb crumbStore
+
+// getCrumb returns the cached crumb, fetching a new one with the given
+// client and context if none is set.
+func getCrumb(ctx context.Context, client *http.Client) (string, error) {
+	yCrumb.mu.RLock()
+	crumb := yCrumb.value
+	yCrumb.mu.RUnlock()
+	if crumb != "" {
+		return crumb, nil
+	}
+	return fetchCrumb(ctx, client, false)
+}
+
+// SetCrumb sets the crumb used to authorize yfin requests. This is useful
+// if you already hold a valid yahoo session, in which case requests skip
+// the crumb bootstrap entirely. The session cookies that the crumb belongs
+// to should be supplied with SetCookieJar or SetHTTPClient.
+func SetCrumb(crumb string) {
+	yCrumb.mu.Lock()
+	defer yCrumb.mu.Unlock()
+	yCrumb.value = crumb
+}
+
+// refreshCrumb replaces a crumb rejected by yahoo. A new session is
+// requested even if the client already holds cookies, since they may
+// have expired along with the crumb. A crumb refreshed by another
+// goroutine in the meantime is returned as is.
+func refreshCrumb(ctx context.Context, client *http.Client, stale string) (string, error) {
+	yCrumb.mu.Lock()
+	crumb := yCrumb.value
+	if crumb == stale {
+		yCrumb.value = ""
+	}
+	yCrumb.mu.Unlock()
+	if crumb != "" && crumb != stale {
+		return crumb, nil
+	}
+	return fetchCrumb(ctx, client, true)
+}
+
+// fetchCrumb fetches a crumb and caches it. Concurrent callers share a
+// single fetch, without holding the lock on the crumb, and each waits
+// for it only until its own context is done. The fetch itself keeps
+// the deadline of the context that started it, but not its cancellation.
+func fetchCrumb(ctx context.Context, client *http.Client, newSession bool) (string, error) {
+	// Fetches are only shared by callers using the
+	// same client, since crumbs belong to its session.
+	key := fmt.Sprintf("%p %t", client, newSession)
+	ch := yCrumb.fetch.DoChan(key, func() (interface{}, error) {
+		// Another goroutine may have fetched the
+		// crumb since the caller last checked.
+		yCrumb.mu.RLock()
+		crumb := yCrumb.value
+		yCrumb.mu.RUnlock()
+		if crumb != "" && !newSession {
+			return crumb, nil
+		}
+
+		fetchCtx := context.WithoutCancel(ctx)
+		if deadline, ok := ctx.Deadline(); ok {
+			var cancel context.CancelFunc
+			fetchCtx, cancel = context.WithDeadline(fetchCtx, deadline)
+			defer cancel()
+		}
+
+		crumb, err := getYahooCrumb(fetchCtx, client, newSession)
+		if err != nil {
+			return "", err
+		}
+
+		yCrumb.mu.Lock()
+		yCrumb.value = crumb
+		yCrumb.mu.Unlock()
+		return crumb, nil
+	})
+
+	select {
+	case res := <-ch:
+		crumb, _ := res.Val.(string)
+		return crumb, res.Err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// setCrumbQuery sets the crumb query parameter on a request,
+// replacing any crumb that was previously set.
+func setCrumbQuery(req *http.Request, crumb string) {
+	query := req.URL.Query()
+	query.Set("crumb", crumb)
+	req.URL.RawQuery = query.Encode()
+}
+
+// getYahooCrumb fetches a new crumb from yahoo. The session cookies
+// are only requested if the client's jar doesn't already hold them,
+// unless newSession is set.
+func getYahooCrumb(ctx context.Context, client *http.Client, newSession bool) (string, error) {
+	if newSession || !hasYahooCookies(client) {
+		req, err := http.NewRequestWithContext(ctx, "GET", yahooSessionURL, nil)
+		if err != nil {
+			return "", err
+		}
+		setBrowserHeaders(req)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+
+		io.Copy(ioutil.Discard, resp.Body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", yahooCrumbURL, nil)
+	if err != nil {
+		return "", err
+	}
+	setBrowserHeaders(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	// A rejected crumb request, such as a rate limited one,
+	// carries an error body rather than a crumb.
+	if resp.StatusCode >= 400 {
+		return "", &RemoteError{
+			Msg:        "cannot fetch yahoo crumb",
+			StatusCode: resp.StatusCode,
+			Body:       string(b),
+		}
+	}
+
+	return string(b), nil
+}
+
+// hasYahooCookies reports whether the client's cookie jar
+// already holds cookies for the yahoo finance session.
+func hasYahooCookies(client *http.Client) bool {
+	if client.Jar == nil {
+		return false
+	}
+	u, err := url.Parse(yahooSessionURL)
+	if err != nil {
+		return false
+	}
+	return len(client.Jar.Cookies(u)) > 0
+}
@@ -1,9 +1,12 @@
 package crypto
 
 import (
+	"fmt"
+	"net/http"
 	"testing"
 
 	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/financetest"
 	tests "github.com/fijoyapp/finance-go/testing"
 	"github.com/stretchr/testify/assert"
 )
@@ -18,3 +21,29 @@ func TestGetCryptoPair(t *testing.T) {
 	assert.Equal(t, finance.MarketStateRegular, q.MarketState)
 	assert.Equal(t, tests.TestCryptoPairSymbol, q.Symbol)
 }
+
+func TestGetCryptoPairFields(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"quoteResponse":{"result":[{
+			"symbol":"BTC-USD",
+			"quoteType":"CRYPTOCURRENCY",
+			"circulatingSupply":19500000,
+			"marketCap":1300000000000,
+			"volume24Hr":25000000000,
+			"fromCurrency":"BTC",
+			"toCurrency":"USD=X",
+			"lastMarket":"CoinMarketCap"
+		}]}}`)
+	}))
+	c := Client{B: backend}
+
+	iter := c.ListP(&Params{Symbols: []string{"BTC-USD"}})
+	assert.True(t, iter.Next())
+	q := iter.CryptoPair()
+	assert.Equal(t, 19500000, q.CirculatingSupply)
+	assert.Equal(t, int64(1300000000000), q.MarketCap)
+	assert.Equal(t, 25000000000, q.VolumeLastDay)
+	assert.Equal(t, "BTC", q.FromCurrency)
+	assert.Equal(t, "USD=X", q.ToCurrency)
+	assert.Equal(t, "CoinMarketCap", q.LastMarket)
+}
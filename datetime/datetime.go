@@ -1,6 +1,10 @@
 package datetime
 
 import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -55,7 +59,12 @@ type Datetime struct {
 	Day   int
 	Month int
 	Year  int
-	t     *time.Time
+	// Location is the timezone the date fields are in,
+	// typically that of the exchange. Defaults to time.Local.
+	// It is ignored by datetimes holding a go time, such as
+	// those from New, Now or FromUnix, which are in its zone.
+	Location *time.Location
+	t        *time.Time
 }
 
 // New creates a new instance of Datetime from a go time struct.
@@ -70,31 +79,135 @@ func New(t *time.Time) *Datetime {
 }
 
 // FromUnix returns a new instance of Datetime from a unix timestamp.
+// The date fields are those of the timestamp in UTC, so the same
+// timestamp yields the same Datetime regardless of the host timezone.
 func FromUnix(timestamp int) *Datetime {
-	t := time.Unix(int64(timestamp), 0)
+	t := time.Unix(int64(timestamp), 0).UTC()
 	return New(&t)
 }
 
-// Time returns a go time struct from a datetime.
+// Now returns a new instance of Datetime for the current time.
+func Now() *Datetime {
+	t := time.Now()
+	return New(&t)
+}
+
+// Today returns a new instance of Datetime for midnight
+// of the current day in the local timezone.
+func Today() *Datetime {
+	year, month, day := time.Now().Date()
+	t := time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+	return New(&t)
+}
+
+// AddDays returns a new instance of Datetime n days after d,
+// or before it if n is negative.
+func (d *Datetime) AddDays(n int) *Datetime {
+	if d.t != nil {
+		t := d.t.AddDate(0, 0, n)
+		shifted := New(&t)
+		shifted.Location = d.Location
+		return shifted
+	}
+
+	t := time.Date(d.Year, time.Month(d.Month), d.Day+n, 0, 0, 0, 0, time.UTC)
+	return &Datetime{
+		Day:      t.Day(),
+		Month:    int(t.Month()),
+		Year:     t.Year(),
+		Location: d.Location,
+	}
+}
+
+// dateLayouts are the calendar date layouts accepted by Parse,
+// in addition to RFC3339.
+var dateLayouts = []string{"2006-01-02", "01/02/2006"}
+
+// Parse returns a new instance of Datetime from a string in RFC3339,
+// YYYY-MM-DD or MM/DD/YYYY format. Calendar dates only set the date
+// fields, just like a Datetime built by hand.
+func Parse(s string) (*Datetime, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return New(&t), nil
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &Datetime{Day: t.Day(), Month: int(t.Month()), Year: t.Year()}, nil
+		}
+	}
+	return nil, fmt.Errorf("datetime: cannot parse %q, expected one of %s, %s", s, time.RFC3339, strings.Join(dateLayouts, ", "))
+}
+
+// Time returns a go time struct from a datetime. Datetimes built
+// from their fields are computed on every call, so changes to the
+// fields or Location are always taken into account.
 func (d *Datetime) Time() *time.Time {
 	if d.t != nil {
 		return d.t
 	}
-	d.calculateTime()
-	return d.Time()
+	t := d.calculateTime()
+	return &t
 }
 
 // Unix returns a valid unix timestamp from Datetime fields.
 func (d *Datetime) Unix() int {
+	return int(d.Time().Unix())
+}
+
+// isZero reports whether the datetime is unset.
+func (d *Datetime) isZero() bool {
 	if d.t != nil {
-		return int(d.t.Unix())
+		return d.t.IsZero()
+	}
+	return d.Year == 0 && d.Month == 0 && d.Day == 0
+}
+
+// calculateTime returns the time of the date fields
+// at the 9:30 market open in Location.
+func (d *Datetime) calculateTime() time.Time {
+	loc := d.Location
+	if loc == nil {
+		loc = time.Local
 	}
+	return time.Date(d.Year, time.Month(d.Month), d.Day, 9, 30, 0, 0, loc)
+}
 
-	d.calculateTime()
-	return d.Unix()
+// MarshalJSON encodes a datetime as a unix timestamp,
+// or as null if the datetime is zero.
+func (d Datetime) MarshalJSON() ([]byte, error) {
+	if d.isZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.Unix())
 }
 
-func (d *Datetime) calculateTime() {
-	t := time.Date(d.Year, time.Month(d.Month), d.Day, 9, 30, 0, 0, time.Local)
-	d.t = &t
+// UnmarshalJSON decodes a datetime from either a unix timestamp,
+// given as a number or a string, or an RFC3339 string.
+// Yahoo uses all of these forms inconsistently.
+func (d *Datetime) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	switch t := v.(type) {
+	case nil:
+		return nil
+	case float64:
+		*d = *FromUnix(int(t))
+		return nil
+	case string:
+		if secs, err := strconv.Atoi(t); err == nil {
+			*d = *FromUnix(secs)
+			return nil
+		}
+		parsed, err := time.Parse(time.RFC3339, t)
+		if err != nil {
+			return fmt.Errorf("datetime: cannot parse %q as a unix timestamp or RFC3339 time", t)
+		}
+		*d = *New(&parsed)
+		return nil
+	}
+
+	return fmt.Errorf("datetime: cannot parse %s as a unix timestamp or RFC3339 time", data)
 }
@@ -0,0 +1,116 @@
+package datetime
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalJSON(t *testing.T) {
+	expected := time.Date(2018, 1, 11, 14, 30, 0, 0, time.UTC).Unix()
+
+	for _, data := range []string{`1515681000`, `"1515681000"`, `"2018-01-11T14:30:00Z"`, `"2018-01-11T09:30:00-05:00"`} {
+		var d Datetime
+		assert.Nil(t, json.Unmarshal([]byte(data), &d), data)
+		assert.Equal(t, int(expected), d.Unix(), data)
+	}
+
+	var d Datetime
+	assert.NotNil(t, json.Unmarshal([]byte(`"yesterday"`), &d))
+}
+
+func TestMarshalJSON(t *testing.T) {
+	d := FromUnix(1515681000)
+
+	data, err := json.Marshal(struct{ T Datetime }{*d})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"T":1515681000}`, string(data))
+}
+
+func TestMarshalZeroJSON(t *testing.T) {
+	data, err := json.Marshal(struct{ T Datetime }{})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"T":null}`, string(data))
+
+	var v struct{ T Datetime }
+	assert.Nil(t, json.Unmarshal(data, &v))
+	assert.Equal(t, Datetime{}, v.T)
+}
+
+func TestParse(t *testing.T) {
+	for _, s := range []string{"2024-01-15", "01/15/2024"} {
+		d, err := Parse(s)
+		assert.Nil(t, err, s)
+		assert.Equal(t, &Datetime{Day: 15, Month: 1, Year: 2024}, d, s)
+	}
+
+	d, err := Parse("2024-01-15T14:30:00Z")
+	assert.Nil(t, err)
+	assert.Equal(t, int(time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC).Unix()), d.Unix())
+
+	d, err = Parse("15.01.2024")
+	assert.Nil(t, d)
+	assert.EqualError(t, err, `datetime: cannot parse "15.01.2024", expected one of 2006-01-02T15:04:05Z07:00, 2006-01-02, 01/02/2006`)
+}
+
+func TestFromUnix(t *testing.T) {
+	local := time.Local
+	defer func() { time.Local = local }()
+
+	for _, name := range []string{"UTC", "America/New_York", "Pacific/Auckland"} {
+		loc, err := time.LoadLocation(name)
+		assert.Nil(t, err)
+		time.Local = loc
+
+		d := FromUnix(1515715200)
+		assert.Equal(t, 2018, d.Year, name)
+		assert.Equal(t, 1, d.Month, name)
+		assert.Equal(t, 12, d.Day, name)
+		assert.Equal(t, 1515715200, d.Unix(), name)
+	}
+}
+
+func TestLocation(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	assert.Nil(t, err)
+
+	d := &Datetime{Year: 2024, Month: 6, Day: 1, Location: sydney}
+	assert.Equal(t, int(time.Date(2024, 6, 1, 9, 30, 0, 0, sydney).Unix()), d.Unix())
+
+	d = &Datetime{Year: 2024, Month: 6, Day: 1}
+	assert.Equal(t, int(time.Date(2024, 6, 1, 9, 30, 0, 0, time.Local).Unix()), d.Unix())
+
+	// Setting Location after the time was read still applies.
+	d.Location = sydney
+	assert.Equal(t, int(time.Date(2024, 6, 1, 9, 30, 0, 0, sydney).Unix()), d.Unix())
+	assert.Equal(t, sydney, d.Time().Location())
+
+	// Datetimes holding a go time keep its zone.
+	now := time.Now().UTC()
+	d = New(&now)
+	d.Location = sydney
+	assert.Equal(t, int(now.Unix()), d.Unix())
+}
+
+func TestToday(t *testing.T) {
+	today := Today()
+	now := time.Now()
+	assert.Equal(t, now.Year(), today.Year)
+	assert.Equal(t, int(now.Month()), today.Month)
+	assert.Equal(t, now.Day(), today.Day)
+	assert.Equal(t, 0, today.Time().Hour())
+	assert.False(t, Now().Time().Before(*today.Time()))
+}
+
+func TestAddDays(t *testing.T) {
+	d := (&Datetime{Year: 2024, Month: 3, Day: 1}).AddDays(-1)
+	assert.Equal(t, &Datetime{Year: 2024, Month: 2, Day: 29}, d)
+
+	d = FromUnix(1515715200).AddDays(30)
+	assert.Equal(t, 1515715200+30*86400, d.Unix())
+	assert.Equal(t, 2018, d.Year)
+	assert.Equal(t, 2, d.Month)
+	assert.Equal(t, 11, d.Day)
+}
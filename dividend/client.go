@@ -0,0 +1,90 @@
+package dividend
+
+import (
+	"sort"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/chart"
+	"github.com/fijoyapp/finance-go/datetime"
+	form "github.com/fijoyapp/finance-go/form"
+	"github.com/fijoyapp/finance-go/iter"
+)
+
+// Client is used to invoke dividend APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and dividend history information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Symbol string `form:"-"`
+	// Start and End bound the dividend history.
+	// The full history is requested if Start is nil.
+	Start *datetime.Datetime `form:"-"`
+	End   *datetime.Datetime `form:"-"`
+}
+
+// Iter is a structure containing results
+// and related metadata for a
+// yfin dividend history request.
+type Iter struct {
+	*iter.Iter
+}
+
+// Dividend returns the next Dividend
+// visited by a call to Next.
+func (i *Iter) Dividend() *finance.Dividend {
+	return i.Current().(*finance.Dividend)
+}
+
+// Get returns a dividend history in chronological order
+// and requires a params struct as an argument.
+func Get(params *Params) *Iter {
+	return getC().Get(params)
+}
+
+// Get returns a dividend history in chronological order.
+// Symbols that never paid a dividend yield an empty iterator.
+func (c Client) Get(params *Params) *Iter {
+
+	if params == nil || len(params.Symbol) == 0 {
+		return &Iter{iter.NewE(finance.CreateArgumentError())}
+	}
+
+	return &Iter{iter.New(nil, func(b *form.Values) (interface{}, []interface{}, error) {
+
+		events, err := chart.Client{B: c.B}.GetEvents(&chart.EventsParams{
+			Params: params.Params,
+			Symbol: params.Symbol,
+			Events: "div",
+			Start:  params.Start,
+			End:    params.End,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		dividends := make([]*finance.Dividend, 0, len(events.Dividends))
+		for _, d := range events.Dividends {
+			dividends = append(dividends, d)
+		}
+		sort.Slice(dividends, func(i, j int) bool {
+			return dividends[i].Date.Unix() < dividends[j].Date.Unix()
+		})
+
+		values := make([]interface{}, len(dividends))
+		for i, d := range dividends {
+			values[i] = d
+		}
+
+		return nil, values, nil
+	})}
+}
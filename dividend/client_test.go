@@ -0,0 +1,107 @@
+package dividend
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/fijoyapp/finance-go/datetime"
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient returns a client for a server responding with
+// the given chart events and recording the request query.
+func newTestClient(t *testing.T, events string, query *url.Values) Client {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v8/finance/chart/AAPL", r.URL.Path)
+		*query = r.URL.Query()
+		w.Write([]byte(`{"chart":{"result":[{"events":` + events + `}],"error":null}}`))
+	}))
+	return Client{B: backend}
+}
+
+func TestGetDividends(t *testing.T) {
+	var query url.Values
+	c := newTestClient(t, `{"dividends":{
+		"1707489000":{"amount":0.24,"date":1707489000},
+		"1699626600":{"amount":0.24,"date":1699626600}
+	}}`, &query)
+
+	iter := c.Get(&Params{Symbol: "AAPL"})
+
+	assert.True(t, iter.Next())
+	assert.Equal(t, 0.24, iter.Dividend().Amount)
+	assert.Equal(t, 1699626600, iter.Dividend().Date.Unix())
+	assert.True(t, iter.Next())
+	assert.Equal(t, 1707489000, iter.Dividend().Date.Unix())
+	assert.False(t, iter.Next())
+	assert.Nil(t, iter.Err())
+	assert.Equal(t, "div", query.Get("events"))
+	assert.Equal(t, "max", query.Get("range"))
+}
+
+func TestGetDividendsRange(t *testing.T) {
+	var query url.Values
+	c := newTestClient(t, `{"dividends":{"1502371800":{"amount":0.63,"date":1502371800}}}`, &query)
+
+	start := &datetime.Datetime{Month: 1, Day: 1, Year: 2017}
+	end := &datetime.Datetime{Month: 1, Day: 1, Year: 2018}
+	iter := c.Get(&Params{Symbol: "AAPL", Start: start, End: end})
+
+	assert.True(t, iter.Next())
+	assert.Equal(t, 0.63, iter.Dividend().Amount)
+	assert.Nil(t, iter.Err())
+	assert.Empty(t, query.Get("range"))
+	assert.Equal(t, strconv.Itoa(start.Unix()), query.Get("period1"))
+	assert.Equal(t, strconv.Itoa(end.Unix()), query.Get("period2"))
+}
+
+func TestGetNoDividends(t *testing.T) {
+	var query url.Values
+	c := newTestClient(t, `null`, &query)
+
+	iter := c.Get(&Params{Symbol: "AAPL"})
+
+	assert.False(t, iter.Next())
+	assert.Nil(t, iter.Err())
+}
+
+func TestBadRangeDividends(t *testing.T) {
+	p := &Params{
+		Symbol: "AAPL",
+		Start:  &datetime.Datetime{Month: 1, Day: 1, Year: 2018},
+		End:    &datetime.Datetime{Month: 1, Day: 1, Year: 2017},
+	}
+	iter := Get(p)
+
+	assert.False(t, iter.Next())
+	assert.NotNil(t, iter.Err())
+}
+
+func TestProjectedAnnualIncome(t *testing.T) {
+	now := time.Now()
+	var period1, period2 int64
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		period1, _ = strconv.ParseInt(r.URL.Query().Get("period1"), 10, 64)
+		period2, _ = strconv.ParseInt(r.URL.Query().Get("period2"), 10, 64)
+
+		// The last payment was cut and the one before
+		// falls just outside of the trailing year.
+		fmt.Fprintf(w, `{"chart":{"result":[{"events":{"dividends":{
+			"a":{"amount":0.5,"date":%d},
+			"b":{"amount":0.5,"date":%d},
+			"c":{"amount":0.25,"date":%d}
+		}}}]}}`, now.AddDate(0, -13, 0).Unix(), now.AddDate(0, -9, 0).Unix(), now.AddDate(0, -1, 0).Unix())
+	}))
+	c := Client{B: backend}
+
+	income, err := c.ProjectedAnnualIncome("T", 100)
+	assert.Nil(t, err)
+	assert.InDelta(t, 75, income, 1e-9)
+	assert.InDelta(t, now.AddDate(-1, 0, 0).Unix(), period1, 5)
+	assert.InDelta(t, now.Unix(), period2, 5)
+}
@@ -0,0 +1,46 @@
+package dividend
+
+import (
+	"time"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/datetime"
+)
+
+// ProjectedAnnualIncome returns the dividend income of
+// holding shares of a symbol over the trailing 12 months.
+func ProjectedAnnualIncome(symbol string, shares float64) (float64, error) {
+	return getC().ProjectedAnnualIncome(symbol, shares)
+}
+
+// ProjectedAnnualIncome returns the dividend income of holding shares
+// of a symbol, projected from the dividends with an ex-dividend date in
+// the trailing 12 months. Only the amounts actually paid are summed, so
+// cut or suspended dividends lower the projection rather than being
+// annualized from the latest payment.
+func (c Client) ProjectedAnnualIncome(symbol string, shares float64) (float64, error) {
+	if len(symbol) == 0 {
+		return 0, finance.CreateArgumentError()
+	}
+
+	now := time.Now()
+	start := now.AddDate(-1, 0, 0)
+	it := c.Get(&Params{
+		Symbol: symbol,
+		Start:  datetime.FromUnix(int(start.Unix())),
+		End:    datetime.FromUnix(int(now.Unix())),
+	})
+
+	total := 0.0
+	for it.Next() {
+		d := it.Dividend()
+		if int64(d.Date.Unix()) >= start.Unix() {
+			total += d.Amount
+		}
+	}
+	if it.Err() != nil {
+		return 0, it.Err()
+	}
+
+	return total * shares, nil
+}
@@ -1,7 +1,25 @@
 package finance
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrNotFound is matched by errors for unknown symbols or resources.
+	ErrNotFound = errors.New("finance: not found")
+	// ErrRateLimited is matched by errors for rate limited requests.
+	ErrRateLimited = errors.New("finance: rate limited")
+	// ErrUnauthorized is matched by errors for rejected credentials.
+	ErrUnauthorized = errors.New("finance: unauthorized")
+	// ErrInvalidCrumb is matched by errors for requests rejected
+	// because of an invalid crumb. Such errors match ErrUnauthorized too.
+	ErrInvalidCrumb = errors.New("finance: invalid crumb")
+	// ErrCircuitOpen is returned for requests that were not sent
+	// because a backend's circuit breaker is open.
+	ErrCircuitOpen = errors.New("finance: circuit open")
 )
 
 const (
@@ -21,6 +39,12 @@ func CreateArgumentError() error {
 	return fmt.Errorf("code: %s, detail: %s", apiErrorCode, "missing function argument")
 }
 
+// CreateArgumentErrorS returns an error
+// with a message about an invalid argument.
+func CreateArgumentErrorS(str string) error {
+	return fmt.Errorf("code: %s, detail: %s", apiErrorCode, str)
+}
+
 // CreateChartTimeError returns an error
 // with a message improper chart arguments.
 func CreateChartTimeError() error {
@@ -38,3 +62,27 @@ func CreateRemoteError(e error) error {
 func CreateRemoteErrorS(str string) error {
 	return fmt.Errorf("code: %s, detail: %s", remoteErrorCode, str)
 }
+
+// CreateChartRangeError returns an error
+// with a message about an unsupported chart range.
+func CreateChartRangeError(rng string) error {
+	return fmt.Errorf("code: %s, detail: unsupported chart range %q", apiErrorCode, rng)
+}
+
+// sentinels returns the sentinel errors matching
+// a status code and a parsed yfin error.
+func sentinels(status int, code, description string) []error {
+	code = strings.ToLower(code)
+	switch {
+	case status == http.StatusTooManyRequests || code == "too many requests":
+		return []error{ErrRateLimited}
+	case strings.Contains(strings.ToLower(description), "crumb"):
+		return []error{ErrInvalidCrumb, ErrUnauthorized}
+	case status == http.StatusUnauthorized || status == http.StatusForbidden ||
+		code == "unauthorized" || code == "forbidden":
+		return []error{ErrUnauthorized}
+	case status == http.StatusNotFound || code == "not found":
+		return []error{ErrNotFound}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package esg
+
+import (
+	"errors"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/quotesummary"
+)
+
+// ErrNoESGData is returned for symbols without
+// sustainability scores, such as most funds.
+var ErrNoESGData = errors.New("no esg data for symbol")
+
+// Client is used to invoke sustainability APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and symbol information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Symbol string `form:"-"`
+}
+
+// Get returns the esg score for a symbol.
+func Get(symbol string) (*finance.ESGScore, error) {
+	return GetP(&Params{Symbol: symbol})
+}
+
+// GetP returns an esg score and requires a params
+// struct as an argument.
+func GetP(params *Params) (*finance.ESGScore, error) {
+	return getC().GetP(params)
+}
+
+// GetP returns an esg score.
+func (c Client) GetP(params *Params) (*finance.ESGScore, error) {
+	if params == nil || len(params.Symbol) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	summary, err := quotesummary.Client{B: c.B}.GetP(&quotesummary.Params{
+		Params:  params.Params,
+		Symbol:  params.Symbol,
+		Modules: []string{quotesummary.ModuleESGScores},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	score := summary.ESGScores
+	if score == nil || score.TotalESG == 0 {
+		return nil, ErrNoESGData
+	}
+	score.Symbol = params.Symbol
+
+	return score, nil
+}
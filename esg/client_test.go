@@ -0,0 +1,45 @@
+package esg
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient returns a client for a server
+// responding with the given quote summary result.
+func newTestClient(t *testing.T, result string) Client {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"quoteSummary":{"result":[%s]}}`, result)
+	}))
+	return Client{B: backend}
+}
+
+func TestGetESG(t *testing.T) {
+	c := newTestClient(t, `{"esgScores":{
+		"totalEsg":17.2,"environmentScore":0.6,"socialScore":7.4,"governanceScore":9.2,
+		"percentile":12.5,"highestControversy":3,"peerGroup":"Technology Hardware",
+		"ratingYear":2024,"ratingMonth":5
+	}}`)
+
+	score, err := c.GetP(&Params{Symbol: "AAPL"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, score)
+	assert.Equal(t, "AAPL", score.Symbol)
+	assert.Equal(t, 17.2, score.TotalESG)
+	assert.Equal(t, 3, score.ControversyLevel)
+	assert.Equal(t, "Technology Hardware", score.PeerGroup)
+}
+
+func TestGetESGNoData(t *testing.T) {
+	c := newTestClient(t, `{}`)
+
+	score, err := c.GetP(&Params{Symbol: "INPSX"})
+
+	assert.Nil(t, score)
+	assert.Equal(t, ErrNoESGData, err)
+}
@@ -1,9 +1,12 @@
 package etf
 
 import (
+	"context"
+	"net/http"
 	"testing"
 
 	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/financetest"
 	tests "github.com/fijoyapp/finance-go/testing"
 	"github.com/stretchr/testify/assert"
 )
@@ -56,3 +59,83 @@ func TestGetBadETF(t *testing.T) {
 	assert.Nil(t, q)
 	assert.Nil(t, err)
 }
+
+func TestGetETFProfile(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v10/finance/quoteSummary/SPY", r.URL.Path)
+		assert.Equal(t, "fundProfile,topHoldings", r.URL.Query().Get("modules"))
+		w.Write([]byte(`{"quoteSummary":{"result":[{
+			"fundProfile":{"family":"SPDR State Street Global Advisors","categoryName":"Large Blend",
+				"feesExpensesInvestment":{"annualReportExpenseRatio":0.000945}},
+			"topHoldings":{"stockPosition":0.9994,
+				"holdings":[{"symbol":"MSFT","holdingName":"Microsoft Corp","holdingPercent":0.0711}],
+				"sectorWeightings":[{"technology":0.2905},{"healthcare":0.1263}]}
+		}]}}`))
+	}))
+	c := Client{B: backend}
+
+	profile, err := c.GetProfile("SPY")
+
+	assert.Nil(t, err)
+	assert.NotNil(t, profile)
+	assert.Equal(t, "SPY", profile.Symbol)
+	assert.Equal(t, "Large Blend", profile.Category)
+	assert.Equal(t, 0.000945, *profile.ExpenseRatio)
+	assert.Len(t, profile.Holdings, 1)
+	assert.Equal(t, "MSFT", profile.Holdings[0].Symbol)
+	assert.Equal(t, map[string]float64{"technology": 0.2905, "healthcare": 0.1263}, profile.SectorWeightings)
+}
+
+func TestGetETFProfileContext(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent with a canceled context")
+	}))
+	c := Client{B: backend}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p := &ProfileParams{Symbol: "SPY"}
+	p.Context = &ctx
+
+	profile, err := c.GetProfileP(p)
+	assert.Nil(t, profile)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGetETFProfileNoSymbol(t *testing.T) {
+	profile, err := GetProfile("")
+
+	assert.Nil(t, profile)
+	assert.Equal(t, "code: api-error, detail: missing function argument", err.Error())
+}
+
+func TestNewETFProfile(t *testing.T) {
+	ratio, stocks := 0.0009, 0.99
+	summary := &finance.QuoteSummary{
+		FundProfile: &finance.FundProfile{
+			Family:       "Vanguard",
+			CategoryName: "Large Blend",
+			Fees:         &finance.FundFees{NetExpenseRatio: &ratio},
+		},
+		TopHoldings: &finance.TopHoldings{
+			StockPosition: &stocks,
+			Holdings:      []*finance.FundHolding{{Symbol: "AAPL", Name: "Apple Inc", Percent: 0.07}},
+			SectorWeightings: []map[string]float64{
+				{"technology": 0.3},
+				{"healthcare": 0.1},
+			},
+		},
+	}
+
+	profile := newProfile("VTI", summary)
+	assert.Equal(t, "Large Blend", profile.Category)
+	assert.Equal(t, 0.0009, *profile.ExpenseRatio)
+	assert.Equal(t, "AAPL", profile.Holdings[0].Symbol)
+	assert.Equal(t, map[string]float64{"technology": 0.3, "healthcare": 0.1}, profile.SectorWeightings)
+	assert.Empty(t, profile.BondRatings)
+
+	empty := newProfile("TEST", &finance.QuoteSummary{})
+	assert.NotNil(t, empty.Holdings)
+	assert.Empty(t, empty.Holdings)
+	assert.Empty(t, empty.SectorWeightings)
+}
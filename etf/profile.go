@@ -0,0 +1,94 @@
+package etf
+
+import (
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/quotesummary"
+)
+
+// ProfileParams carries a context and symbol information.
+type ProfileParams struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Symbol string `form:"-"`
+}
+
+// GetProfile returns the profile and holdings of an etf.
+func GetProfile(symbol string) (*finance.ETFProfile, error) {
+	return GetProfileP(&ProfileParams{Symbol: symbol})
+}
+
+// GetProfileP returns the profile and holdings of an etf
+// and requires a params struct as an argument.
+func GetProfileP(params *ProfileParams) (*finance.ETFProfile, error) {
+	return getC().GetProfileP(params)
+}
+
+// GetProfile returns the profile and holdings of an etf.
+func (c Client) GetProfile(symbol string) (*finance.ETFProfile, error) {
+	return c.GetProfileP(&ProfileParams{Symbol: symbol})
+}
+
+// GetProfileP returns the profile and holdings of an etf.
+// Funds without holdings data return a profile with
+// empty holdings and weightings.
+func (c Client) GetProfileP(params *ProfileParams) (*finance.ETFProfile, error) {
+	if params == nil || len(params.Symbol) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	summary, err := quotesummary.Client{B: c.B}.GetP(&quotesummary.Params{
+		Params:  params.Params,
+		Symbol:  params.Symbol,
+		Modules: []string{quotesummary.ModuleFundProfile, quotesummary.ModuleTopHoldings},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newProfile(params.Symbol, summary), nil
+}
+
+// newProfile builds an etf profile from quote summary modules.
+func newProfile(symbol string, summary *finance.QuoteSummary) *finance.ETFProfile {
+	profile := &finance.ETFProfile{
+		Symbol:           symbol,
+		Holdings:         []*finance.FundHolding{},
+		SectorWeightings: map[string]float64{},
+		BondRatings:      map[string]float64{},
+	}
+
+	if fp := summary.FundProfile; fp != nil {
+		profile.Family = fp.Family
+		profile.Category = fp.CategoryName
+		if fp.Fees != nil {
+			profile.ExpenseRatio = fp.Fees.AnnualReportExpenseRatio
+			if profile.ExpenseRatio == nil {
+				profile.ExpenseRatio = fp.Fees.NetExpenseRatio
+			}
+			profile.TotalNetAssets = fp.Fees.TotalNetAssets
+		}
+	}
+
+	if th := summary.TopHoldings; th != nil {
+		profile.StockPosition = th.StockPosition
+		profile.BondPosition = th.BondPosition
+		profile.CashPosition = th.CashPosition
+		if th.Holdings != nil {
+			profile.Holdings = th.Holdings
+		}
+		for _, w := range th.SectorWeightings {
+			for sector, weight := range w {
+				profile.SectorWeightings[sector] = weight
+			}
+		}
+		for _, r := range th.BondRatings {
+			for rating, weight := range r {
+				profile.BondRatings[rating] = weight
+			}
+		}
+	}
+
+	return profile
+}
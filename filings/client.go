@@ -0,0 +1,78 @@
+package filings
+
+import (
+	"sort"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/datetime"
+	"github.com/fijoyapp/finance-go/quotesummary"
+)
+
+// Client is used to invoke sec filings APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and symbol information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Symbol string `form:"-"`
+}
+
+// Get returns the sec filings of a symbol, newest first.
+func Get(symbol string) ([]finance.SECFiling, error) {
+	return GetP(&Params{Symbol: symbol})
+}
+
+// GetP returns sec filings and requires a params
+// struct as an argument.
+func GetP(params *Params) ([]finance.SECFiling, error) {
+	return getC().GetP(params)
+}
+
+// GetP returns sec filings. Filings without
+// an edgar url are left out.
+func (c Client) GetP(params *Params) ([]finance.SECFiling, error) {
+	if params == nil || len(params.Symbol) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	summary, err := quotesummary.Client{B: c.B}.GetP(&quotesummary.Params{
+		Params:  params.Params,
+		Symbol:  params.Symbol,
+		Modules: []string{quotesummary.ModuleSECFilings},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if summary.SECFilings == nil {
+		return nil, nil
+	}
+
+	filings := []finance.SECFiling{}
+	for _, f := range summary.SECFilings.Filings {
+		if f.EdgarURL == "" {
+			continue
+		}
+		filings = append(filings, finance.SECFiling{
+			Type:     f.Type,
+			Title:    f.Title,
+			Date:     *datetime.FromUnix(f.EpochDate),
+			EdgarURL: f.EdgarURL,
+		})
+	}
+
+	sort.SliceStable(filings, func(i, j int) bool {
+		return filings[i].Date.Unix() > filings[j].Date.Unix()
+	})
+
+	return filings, nil
+}
@@ -0,0 +1,55 @@
+package filings
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFilings(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v10/finance/quoteSummary/AAPL", r.URL.Path)
+		assert.Equal(t, "secFilings", r.URL.Query().Get("modules"))
+		fmt.Fprint(w, `{"quoteSummary":{"result":[{"secFilings":{"filings":[
+			{"date":"2024-02-02","epochDate":1706832000,"type":"10-Q","title":"Periodic Financial Reports","edgarUrl":"https://example.com/10-q"}
+		]}}]}}`)
+	}))
+	c := Client{B: backend}
+
+	filings, err := c.GetP(&Params{Symbol: "AAPL"})
+
+	assert.Nil(t, err)
+	assert.Len(t, filings, 1)
+	assert.Equal(t, "10-Q", filings[0].Type)
+	assert.Equal(t, 1706832000, filings[0].Date.Unix())
+}
+
+func TestGetFilingsNoSymbol(t *testing.T) {
+	filings, err := Get("")
+	assert.Nil(t, filings)
+	assert.EqualError(t, err, "code: api-error, detail: missing function argument")
+}
+
+func TestGetFilingsOrder(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"quoteSummary":{"result":[{"secFilings":{"filings":[
+			{"date":"2024-05-03","epochDate":1714694400,"type":"10-Q","title":"Quarterly Report","edgarUrl":"https://example.com/10-q"},
+			{"date":"2024-08-02","epochDate":1722556800,"type":"8-K","title":"Current Report","edgarUrl":""},
+			{"date":"2024-11-01","epochDate":1730419200,"type":"10-K","title":"Annual Report","edgarUrl":"https://example.com/10-k"}
+		]}}]}}`)
+	}))
+	c := Client{B: backend}
+
+	filings, err := c.GetP(&Params{Symbol: "AAPL"})
+	assert.Nil(t, err)
+	assert.Len(t, filings, 2)
+	assert.Equal(t, "10-K", filings[0].Type)
+	assert.Equal(t, "Annual Report", filings[0].Title)
+	assert.Equal(t, "https://example.com/10-k", filings[0].EdgarURL)
+	assert.Equal(t, 11, filings[0].Date.Month)
+	assert.Equal(t, 1, filings[0].Date.Day)
+	assert.Equal(t, "10-Q", filings[1].Type)
+}
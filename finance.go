@@ -1,21 +1,30 @@
 package finance
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"log"
+	"log/slog"
 	"net/http"
 	"net/http/cookiejar"
+	"net/url"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/fijoyapp/finance-go/form"
 	"golang.org/x/net/publicsuffix"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
 )
 
 // Printfer is an interface to be implemented by Logger.
@@ -57,6 +66,7 @@ const (
 	// ------------------
 
 	defaultHTTPTimeout = 80 * time.Second
+	maxRetryBackoff    = 30 * time.Second
 	yFinURL            = "https://query2.finance.yahoo.com"
 	batsURL            = ""
 )
@@ -79,7 +89,11 @@ var (
 
 	httpClient *http.Client
 	backends   Backends
-	yCrumb     string
+	inflight   singleflight.Group
+	limiter    struct {
+		l  *rate.Limiter
+		mu sync.RWMutex
+	}
 )
 
 // SupportedBackend is an enumeration of supported api endpoints.
@@ -96,6 +110,26 @@ type BackendConfiguration struct {
 	Type       SupportedBackend
 	URL        string
 	HTTPClient *http.Client
+
+	// MaxRetries is the number of times a request is retried after a
+	// network error or a transient error response. Defaults to 0.
+	MaxRetries int
+	// RetryBackoff returns how long to wait before the given retry attempt,
+	// starting at 0. Defaults to DefaultRetryBackoff if nil.
+	RetryBackoff func(attempt int) time.Duration
+
+	// Headers are added to every request made by the backend,
+	// overriding both the built-in browser and default headers.
+	Headers http.Header
+
+	// Timeout bounds each call, including retries and fetching the
+	// crumb, on top of any deadline of the call's context and the
+	// HTTP client timeout. Defaults to 0, which adds no deadline.
+	Timeout time.Duration
+
+	// Breaker, if set, fast-fails requests with ErrCircuitOpen
+	// while the api is failing. Defaults to nil, which disables it.
+	Breaker *CircuitBreaker
 }
 
 // Backend is an interface for making calls against an api service.
@@ -107,19 +141,122 @@ type Backend interface {
 // SetHTTPClient overrides the default HTTP client.
 // This is useful if you're running in a Google AppEngine environment
 // where the http.DefaultClient is not available.
+// The client, including its cookie jar, is also used to fetch the crumb.
 func SetHTTPClient(client *http.Client) {
+	backends.mu.Lock()
+	defer backends.mu.Unlock()
+
+	// Backends built from the previous default
+	// client are switched over to the new one.
+	for _, b := range []Backend{backends.YFin, backends.Bats} {
+		if conf, ok := b.(*BackendConfiguration); ok && conf.HTTPClient == httpClient {
+			conf.HTTPClient = client
+		}
+	}
 	httpClient = client
 }
 
+// SetCookieJar sets the cookie jar of the default HTTP client.
+// This is useful if you already hold a valid yahoo session,
+// in which case the crumb bootstrap reuses its cookies.
+func SetCookieJar(jar http.CookieJar) {
+	backends.mu.Lock()
+	defer backends.mu.Unlock()
+	httpClient.Jar = jar
+}
+
+// SetProxy routes the requests of the default HTTP client through
+// a proxy, given as a URL with an http, https or socks5 scheme.
+// This includes the requests made to fetch the crumb. The cookie
+// jar is preserved. An empty URL restores the proxy settings from
+// the environment. It should be called before making any requests.
+func SetProxy(proxyURL string) error {
+	proxy := http.ProxyFromEnvironment
+	if proxyURL != "" {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return err
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return fmt.Errorf("unsupported proxy scheme %q", u.Scheme)
+		}
+		proxy = http.ProxyURL(u)
+	}
+
+	backends.mu.Lock()
+	defer backends.mu.Unlock()
+
+	t := cloneTransport(httpClient.Transport)
+	t.Proxy = proxy
+	httpClient.Transport = t
+	return nil
+}
+
+// SetTLSConfig sets the TLS configuration of the default HTTP client,
+// for example to trust the certificate of a TLS inspecting proxy.
+// It applies to both api calls and the requests made to fetch the
+// crumb. The cookie jar and proxy settings are preserved. It should
+// be called before making any requests.
+func SetTLSConfig(config *tls.Config) {
+	backends.mu.Lock()
+	defer backends.mu.Unlock()
+
+	t := cloneTransport(httpClient.Transport)
+	t.TLSClientConfig = config
+	httpClient.Transport = t
+}
+
+// cloneTransport returns a copy of rt, or of the default
+// transport if rt is nil or not an *http.Transport.
+func cloneTransport(rt http.RoundTripper) *http.Transport {
+	if t, ok := rt.(*http.Transport); ok {
+		return t.Clone()
+	}
+	return http.DefaultTransport.(*http.Transport).Clone()
+}
+
+// SetRateLimit limits all outgoing api calls to rps requests per second,
+// allowing bursts of up to burst requests. Calls block until they are
+// allowed to proceed or their context is done. A rps of 0 or less
+// removes the limit, which is the default.
+func SetRateLimit(rps float64, burst int) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	if rps <= 0 {
+		limiter.l = nil
+		return
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	limiter.l = rate.NewLimiter(rate.Limit(rps), burst)
+}
+
+// waitRateLimit blocks until the rate limiter, if any,
+// allows a request to proceed or the context is done.
+func waitRateLimit(ctx context.Context) error {
+	limiter.mu.RLock()
+	l := limiter.l
+	limiter.mu.RUnlock()
+
+	if l == nil {
+		return nil
+	}
+	return l.Wait(ctx)
+}
+
 // NewBackends creates a new set of backends with the given HTTP client. You
 // should only need to use this for testing purposes or on App Engine.
 func NewBackends(httpClient *http.Client) *Backends {
 	return &Backends{
 		YFin: &BackendConfiguration{
-			YFinBackend, YFinURL, httpClient,
+			Type: YFinBackend, URL: YFinURL, HTTPClient: httpClient,
 		},
 		Bats: &BackendConfiguration{
-			BATSBackend, BATSURL, httpClient,
+			Type: BATSBackend, URL: BATSURL, HTTPClient: httpClient,
 		},
 	}
 }
@@ -136,7 +273,11 @@ func GetBackend(backend SupportedBackend) Backend {
 		}
 		backends.mu.Lock()
 		defer backends.mu.Unlock()
-		backends.YFin = &BackendConfiguration{backend, yFinURL, httpClient}
+		// Another goroutine may have set the
+		// backend while we waited on the lock.
+		if backends.YFin == nil {
+			backends.YFin = &BackendConfiguration{Type: backend, URL: yFinURL, HTTPClient: httpClient}
+		}
 		return backends.YFin
 	case BATSBackend:
 		backends.mu.RLock()
@@ -147,7 +288,11 @@ func GetBackend(backend SupportedBackend) Backend {
 		}
 		backends.mu.Lock()
 		defer backends.mu.Unlock()
-		backends.Bats = &BackendConfiguration{backend, batsURL, httpClient}
+		// Another goroutine may have set the
+		// backend while we waited on the lock.
+		if backends.Bats == nil {
+			backends.Bats = &BackendConfiguration{Type: backend, URL: batsURL, HTTPClient: httpClient}
+		}
 		return backends.Bats
 	}
 
@@ -156,6 +301,9 @@ func GetBackend(backend SupportedBackend) Backend {
 
 // SetBackend sets the backend used in the binding.
 func SetBackend(backend SupportedBackend, b Backend) {
+	backends.mu.Lock()
+	defer backends.mu.Unlock()
+
 	switch backend {
 	case YFinBackend:
 		backends.YFin = b
@@ -176,8 +324,6 @@ func (s *BackendConfiguration) Call(path string, form *form.Values, ctx *context
 		return err
 	}
 
-	setBrowserHeaders(req)
-
 	if err := s.Do(req, v); err != nil {
 		return err
 	}
@@ -185,6 +331,59 @@ func (s *BackendConfiguration) Call(path string, form *form.Values, ctx *context
 	return nil
 }
 
+// CallRaw requests an api path with the given query and returns the raw
+// response body. It goes through the same crumb handling, retries, caching
+// and error parsing as Call, which makes it an escape hatch for endpoints
+// that aren't modeled yet.
+func (s *BackendConfiguration) CallRaw(path string, query url.Values, ctx context.Context) ([]byte, error) {
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	req, err := s.NewRequest("GET", path, &ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.DoRaw(req)
+}
+
+// CallRaw requests a yfin api path with the given query
+// and returns the raw response body. See BackendConfiguration.CallRaw.
+// Backends other than a BackendConfiguration must return json.
+func CallRaw(path string, query url.Values, ctx context.Context) ([]byte, error) {
+	b := GetBackend(YFinBackend)
+	if raw, ok := b.(interface {
+		CallRaw(string, url.Values, context.Context) ([]byte, error)
+	}); ok {
+		return raw.CallRaw(path, query, ctx)
+	}
+
+	body := &form.Values{}
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range query[key] {
+			body.Add(key, value)
+		}
+	}
+
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	var resp json.RawMessage
+	if err := b.Call(path, body, &ctx, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // NewRequest is used by Call to generate an http.Request.
 func (s *BackendConfiguration) NewRequest(method, path string, ctx *context.Context) (*http.Request, error) {
 	if !strings.HasPrefix(path, "/") {
@@ -195,136 +394,420 @@ func (s *BackendConfiguration) NewRequest(method, path string, ctx *context.Cont
 
 	req, err := http.NewRequest(method, path, nil)
 	if err != nil {
-		if LogLevel > 0 {
-			Logger.Printf("Cannot create api request: %v\n", err)
-		}
+		logEvent(context.TODO(), slog.LevelError, "Cannot create api request", "error", err)
 		return nil, err
 	}
 	if ctx != nil {
 		req = req.WithContext(*ctx)
 	}
 
+	setBrowserHeaders(req)
+	for key, values := range s.Headers {
+		req.Header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+	}
+
 	return req, nil
 }
 
 var (
 	UserAgent = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/113.0.0.0 Safari/537.36"
+
+	defaultHeaders struct {
+		h  http.Header
+		mu sync.RWMutex
+	}
 )
 
+// SetDefaultHeaders sets headers that are added to every request,
+// including the crumb bootstrap, overriding the built-in browser
+// headers such as the User-Agent. Headers set on a backend
+// configuration take precedence over these.
+func SetDefaultHeaders(h http.Header) {
+	defaultHeaders.mu.Lock()
+	defer defaultHeaders.mu.Unlock()
+	defaultHeaders.h = h.Clone()
+}
+
+// setBrowserHeaders sets the built-in browser headers on a request,
+// followed by any default headers.
 func setBrowserHeaders(req *http.Request) {
 	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*")
 	req.Header.Set("User-Agent", UserAgent)
+
+	defaultHeaders.mu.RLock()
+	defer defaultHeaders.mu.RUnlock()
+	for key, values := range defaultHeaders.h {
+		req.Header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+	}
 }
 
-func getYahooCrumb(client *http.Client) (string, error) {
-	req, err := http.NewRequest("GET", "https://finance.yahoo.com/", nil)
+// Do is used by Call to execute an API request and parse the response. It uses
+// the backend's HTTP client to execute the request and unmarshals the response
+// into v. It also handles unmarshaling errors returned by the API.
+// Concurrent GET requests for the same URL through the same backend are
+// collapsed into a single request whose response, or error, is shared by
+// all of them. A caller whose context is done stops waiting on the shared
+// request without canceling it for the others.
+func (s *BackendConfiguration) Do(req *http.Request, v interface{}) error {
+	resBody, err := s.doRaw(req)
 	if err != nil {
-		return "", err
+		return err
 	}
-	setBrowserHeaders(req)
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", err
+	if v != nil {
+		return json.Unmarshal(resBody, v)
 	}
-	defer resp.Body.Close()
 
-	io.Copy(ioutil.Discard, resp.Body)
+	return nil
+}
 
-	req, err = http.NewRequest("GET", "https://query2.finance.yahoo.com/v1/test/getcrumb", nil)
+// DoRaw is like Do, but returns the
+// response body instead of unmarshaling it.
+// The body is a copy that the caller may modify.
+func (s *BackendConfiguration) DoRaw(req *http.Request) ([]byte, error) {
+	resBody, err := s.doRaw(req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	setBrowserHeaders(req)
+	return bytes.Clone(resBody), nil
+}
 
-	resp, err = client.Do(req)
-	if err != nil {
-		return "", err
+// doRaw executes a request and returns its response body, which may
+// be shared with the cache and with concurrent identical requests, so
+// it must not be modified. If the request's context carries an
+// OpenTelemetry span, the call is traced in a child span of it.
+func (s *BackendConfiguration) doRaw(req *http.Request) (resBody []byte, err error) {
+	req, span := startSpan(req)
+	cached := false
+	defer func() { endSpan(span, cached, err) }()
+
+	logEvent(req.Context(), slog.LevelInfo, "Requesting", requestFields(req)...)
+
+	if s.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), s.Timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	// The cache key is taken before the
+	// crumb is added, since crumbs change.
+	cache, ttl := getCache(req.URL.Path)
+	key := cacheKey(req)
+	if cache != nil {
+		if body, ok := cache.Get(key); ok {
+			cached = true
+			logEvent(req.Context(), slog.LevelDebug, "Cached API response", requestFields(req, "body", string(body))...)
+			return body, nil
+		}
 	}
-	defer resp.Body.Close()
 
-	b, err := ioutil.ReadAll(resp.Body)
+	// Identical concurrent requests share a single
+	// in-flight request and its response.
+	if req.Method == http.MethodGet {
+		resBody, err = s.doShared(req, key)
+	} else {
+		resBody, err = s.do(req)
+	}
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	logEvent(req.Context(), slog.LevelDebug, "API response", requestFields(req, "body", string(resBody))...)
+
+	if cache != nil {
+		cache.Set(key, resBody, ttl)
 	}
 
-	return string(b), nil
+	return resBody, nil
 }
 
-// Do is used by Call to execute an API request and parse the response. It uses
-// the backend's HTTP client to execute the request and unmarshals the response
-// into v. It also handles unmarshaling errors returned by the API.
-func (s *BackendConfiguration) Do(req *http.Request, v interface{}) error {
-	if LogLevel > 1 {
-		Logger.Printf("Requesting %v %v%v\n", req.Method, req.URL.Host, req.URL.Path)
+// doShared sends a request, sharing it with identical concurrent
+// requests made through the same backend. The shared request keeps the
+// deadline of the caller that started it, including the backend's
+// Timeout, but not its cancellation, so that a caller giving up doesn't
+// fail the others. Each caller stops waiting on it once its own context
+// is done.
+func (s *BackendConfiguration) doShared(req *http.Request, key string) ([]byte, error) {
+	ch := inflight.DoChan(fmt.Sprintf("%p %s", s, key), func() (interface{}, error) {
+		ctx := context.WithoutCancel(req.Context())
+		if deadline, ok := req.Context().Deadline(); ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithDeadline(ctx, deadline)
+			defer cancel()
+		}
+		return s.do(req.WithContext(ctx))
+	})
+
+	select {
+	case res := <-ch:
+		body, _ := res.Val.([]byte)
+		return body, res.Err
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
 	}
+}
 
+// do authorizes and sends a request, refreshing
+// the crumb once if it was rejected.
+func (s *BackendConfiguration) do(req *http.Request) (resBody []byte, err error) {
 	start := time.Now()
+	if s.Breaker != nil {
+		probe, berr := s.Breaker.allow()
+		if berr != nil {
+			logEvent(req.Context(), slog.LevelDebug, "Circuit open", requestFields(req)...)
+			onUnsent(req, start)
+			return nil, berr
+		}
+		defer func() { s.Breaker.record(probe, err) }()
+	}
 
+	var crumb string
 	if s.Type == YFinBackend {
-		if yCrumb == "" {
-			crumb, err := getYahooCrumb(s.HTTPClient)
-			if err != nil {
-				return fmt.Errorf("get yahoo crumb err: %w", err)
-			}
-			yCrumb = crumb
+		var err error
+		crumb, err = getCrumb(req.Context(), s.HTTPClient)
+		if err != nil {
+			onUnsent(req, start)
+			return nil, fmt.Errorf("get yahoo crumb err: %w", err)
+		}
+		setCrumbQuery(req, crumb)
+	}
+
+	resBody, err = s.sendWithRetry(req)
+
+	remoteErr, ok := err.(*RemoteError)
+	if ok && s.Type == YFinBackend && isAuthStatus(remoteErr.StatusCode) {
+		// The crumb, or the session it belongs to, has most likely
+		// expired upstream, so start a new session, fetch a fresh
+		// crumb and retry the request once.
+		crumb, err = refreshCrumb(req.Context(), s.HTTPClient, crumb)
+		if err != nil {
+			onUnsent(req, start)
+			return nil, fmt.Errorf("get yahoo crumb err: %w", err)
 		}
+		setCrumbQuery(req, crumb)
 
-		query := req.URL.Query()
-		query.Add("crumb", yCrumb)
-		req.URL.RawQuery = query.Encode()
+		resBody, err = s.sendWithRetry(req)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	res, err := s.HTTPClient.Do(req)
+	return resBody, nil
+}
 
-	if LogLevel > 2 {
-		Logger.Printf("Completed in %v\n", time.Since(start))
+// DefaultRetryBackoff is the default backoff between retries,
+// doubling from 500ms with each attempt up to a maximum of 30s.
+func DefaultRetryBackoff(attempt int) time.Duration {
+	backoff := 500 * time.Millisecond
+	for i := 0; i < attempt && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
 	}
+	return backoff
+}
 
-	if err != nil {
-		if LogLevel > 0 {
-			Logger.Printf("Request to api failed: %v\n", err)
+// sendWithRetry executes a request, retrying network errors and
+// transient error responses up to MaxRetries times. The last error
+// is returned if every attempt fails.
+func (s *BackendConfiguration) sendWithRetry(req *http.Request) ([]byte, error) {
+	backoff := s.RetryBackoff
+	if backoff == nil {
+		backoff = DefaultRetryBackoff
+	}
+
+	for attempt := 0; ; attempt++ {
+		resBody, err := s.send(req, time.Now(), attempt)
+		if err == nil || attempt >= s.MaxRetries || !isRetryable(err) {
+			return resBody, err
 		}
-		return err
+
+		logEvent(req.Context(), slog.LevelInfo, "Retrying request", requestFields(req, "retry_count", attempt+1, "error", err)...)
+
+		wait := backoff(attempt)
+		if remoteErr, ok := err.(*RemoteError); ok && remoteErr.RetryAfter > wait {
+			wait = remoteErr.RetryAfter
+		}
+
+		if werr := sleepContext(req.Context(), wait); werr != nil {
+			return nil, err
+		}
+	}
+}
+
+// isRetryable reports whether a failed request may succeed if retried.
+func isRetryable(err error) bool {
+	remoteErr, ok := err.(*RemoteError)
+	if !ok {
+		// Network error, unless the
+		// request context is done.
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	switch remoteErr.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// sleepContext waits for the given duration, returning early with an
+// error if the context is done or its deadline would pass first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return context.DeadlineExceeded
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// send executes a single request and reads the response body,
+// returning a RemoteError for any error status code. Attempt
+// is the number of retries before this one, for logging.
+func (s *BackendConfiguration) send(req *http.Request, start time.Time, attempt int) ([]byte, error) {
+	if err := waitRateLimit(req.Context()); err != nil {
+		onUnsent(req, start)
+		return nil, err
+	}
+
+	onRequest(req)
+	res, err := s.HTTPClient.Do(req)
+	if err != nil {
+		onResponse(nil, start)
+		logEvent(req.Context(), slog.LevelError, "Request to api failed",
+			requestFields(req, "duration_ms", durationMS(start), "retry_count", attempt, "error", err)...)
+		return nil, err
 	}
 	defer res.Body.Close()
 
 	resBody, err := ioutil.ReadAll(res.Body)
+	onResponse(res, start)
 	if err != nil {
-		if LogLevel > 0 {
-			Logger.Printf("Cannot parse response: %v\n", err)
-		}
-		return err
+		logEvent(req.Context(), slog.LevelError, "Cannot parse response",
+			requestFields(req, "status", res.StatusCode, "duration_ms", durationMS(start), "retry_count", attempt, "error", err)...)
+		return nil, err
 	}
 
 	if res.StatusCode >= 400 {
-		if LogLevel > 0 {
-			Logger.Printf("API error: %q\n", resBody)
-		}
-		return &RemoteError{
+		logEvent(req.Context(), slog.LevelError, "API error",
+			requestFields(req, "status", res.StatusCode, "duration_ms", durationMS(start), "retry_count", attempt, "body", string(resBody))...)
+		remoteErr := &RemoteError{
 			Msg:        "error response recieved from upstream api",
 			StatusCode: res.StatusCode,
 			Body:       string(resBody),
 		}
+		if yErr := parseYfinError(resBody); yErr != nil {
+			remoteErr.Code = yErr.Code
+			remoteErr.Description = yErr.Description
+		}
+		if res.StatusCode == http.StatusTooManyRequests {
+			remoteErr.RetryAfter = parseRetryAfter(res.Header.Get("Retry-After"), time.Now())
+		}
+		return nil, remoteErr
 	}
 
-	if LogLevel > 2 {
-		Logger.Printf("API response: %q\n", resBody)
-	}
+	logEvent(req.Context(), slog.LevelDebug, "Completed",
+		requestFields(req, "status", res.StatusCode, "duration_ms", durationMS(start), "retry_count", attempt)...)
+	return resBody, nil
+}
 
-	if v != nil {
-		return json.Unmarshal(resBody, v)
+// parseRetryAfter parses a Retry-After header value, given either as
+// a number of seconds or as an HTTP date. It returns 0 if the value
+// is missing or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
 	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
 
-	return nil
+// isAuthStatus reports whether a status code indicates
+// that the request was rejected for authorization reasons.
+func isAuthStatus(code int) bool {
+	return code == http.StatusUnauthorized || code == http.StatusForbidden
 }
 
 type RemoteError struct {
 	Msg        string
 	StatusCode int
 	Body       string
+	// Code and Description are parsed from the yfin
+	// error in the response body, when present.
+	Code        string
+	Description string
+	// RetryAfter is how long the api asked clients to wait
+	// before retrying a rate limited request, if specified.
+	RetryAfter time.Duration
 }
 
 func (e *RemoteError) Error() string {
-	return fmt.Sprintf("status: %d, detail: %s", e.StatusCode, e.Msg)
+	switch {
+	case e.Code != "" || e.Description != "":
+		return fmt.Sprintf("status: %d, detail: %s, code: %s, description: %s", e.StatusCode, e.Msg, e.Code, e.Description)
+	case strings.TrimSpace(e.Body) != "":
+		body := strings.TrimSpace(e.Body)
+		if len(body) > maxErrorBodyLen {
+			// Cut at a rune boundary so the message stays valid UTF-8.
+			n := maxErrorBodyLen
+			for n > 0 && !utf8.RuneStart(body[n]) {
+				n--
+			}
+			body = body[:n] + "..."
+		}
+		return fmt.Sprintf("status: %d, detail: %s, body: %s", e.StatusCode, e.Msg, body)
+	default:
+		return fmt.Sprintf("status: %d, detail: %s", e.StatusCode, e.Msg)
+	}
+}
+
+// Unwrap returns the sentinel errors matching the status code and
+// parsed yfin error, so that errors.Is(err, ErrRateLimited) and
+// friends can be used to tell failure modes apart.
+func (e *RemoteError) Unwrap() []error {
+	return sentinels(e.StatusCode, e.Code, e.Description)
+}
+
+// maxErrorBodyLen caps how much of an unparseable
+// error body is included in RemoteError messages.
+const maxErrorBodyLen = 256
+
+// parseYfinError extracts the yfin error from an error response body.
+// Yahoo nests it under a key named after the endpoint, for example
+// {"finance":{"error":{...}}} or {"chart":{"result":null,"error":{...}}}.
+func parseYfinError(body []byte) *YfinError {
+	outer := map[string]json.RawMessage{}
+	if json.Unmarshal(body, &outer) != nil {
+		return nil
+	}
+	for _, raw := range outer {
+		inner := struct {
+			Error *YfinError `json:"error"`
+		}{}
+		if json.Unmarshal(raw, &inner) == nil && inner.Error != nil {
+			return inner.Error
+		}
+	}
+	return nil
 }
@@ -0,0 +1,1058 @@
+package finance
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fijoyapp/finance-go/form"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newTestBackend returns a yfin backend configuration pointing at
+// the given test server. The crumb it sets is cleared after the test.
+func newTestBackend(t *testing.T, server *httptest.Server) *BackendConfiguration {
+	SetCrumb("test-crumb")
+	t.Cleanup(func() { SetCrumb("") })
+	return &BackendConfiguration{
+		Type:       YFinBackend,
+		URL:        server.URL,
+		HTTPClient: server.Client(),
+	}
+}
+
+func TestSetCrumb(t *testing.T) {
+	var crumb string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		crumb = r.URL.Query().Get("crumb")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	err := newTestBackend(t, server).Call("/v7/finance/quote", nil, nil, &struct{}{})
+	assert.Nil(t, err)
+	assert.Equal(t, "test-crumb", crumb)
+}
+
+func TestRetryTransientErrors(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	b := newTestBackend(t, server)
+	b.MaxRetries = 2
+	b.RetryBackoff = func(int) time.Duration { return 0 }
+
+	err := b.Call("/v7/finance/quote", nil, nil, &struct{}{})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryExhausted(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	b := newTestBackend(t, server)
+	b.MaxRetries = 1
+	b.RetryBackoff = func(int) time.Duration { return 0 }
+
+	err := b.Call("/v7/finance/quote", nil, nil, &struct{}{})
+	remoteErr, ok := err.(*RemoteError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadGateway, remoteErr.StatusCode)
+	assert.Equal(t, 2, calls)
+}
+
+func TestNoRetryByDefault(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := newTestBackend(t, server).Call("/v7/finance/quote", nil, nil, &struct{}{})
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2018, 1, 11, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 120*time.Second, parseRetryAfter("120", now))
+	assert.Equal(t, 30*time.Second, parseRetryAfter("Thu, 11 Jan 2018 12:00:30 GMT", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("Thu, 11 Jan 2018 11:59:00 GMT", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+}
+
+func TestRateLimitedRetryAfter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	err := newTestBackend(t, server).Call("/v7/finance/quote", nil, nil, &struct{}{})
+	remoteErr, ok := err.(*RemoteError)
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, remoteErr.RetryAfter)
+}
+
+func TestRateLimitContextCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	SetRateLimit(0.001, 1)
+	defer SetRateLimit(0, 0)
+
+	b := newTestBackend(t, server)
+	assert.Nil(t, b.Call("/v7/finance/quote", nil, nil, &struct{}{}))
+
+	// The single token is spent, so the next call
+	// must give up once its context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.NotNil(t, b.Call("/v7/finance/quote", nil, &ctx, &struct{}{}))
+}
+
+func TestRequestHeaders(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	SetDefaultHeaders(http.Header{
+		"User-Agent":      {"default-agent"},
+		"Accept-Language": {"en-GB"},
+	})
+	defer SetDefaultHeaders(nil)
+
+	b := newTestBackend(t, server)
+	b.Headers = http.Header{"X-Request-Id": {"abc"}}
+	assert.Nil(t, b.Call("/v7/finance/quote", nil, nil, &struct{}{}))
+	assert.Equal(t, "default-agent", header.Get("User-Agent"))
+	assert.Equal(t, "en-GB", header.Get("Accept-Language"))
+	assert.Equal(t, "abc", header.Get("X-Request-Id"))
+
+	b.Headers.Set("User-Agent", "backend-agent")
+	assert.Nil(t, b.Call("/v7/finance/quote", nil, nil, &struct{}{}))
+	assert.Equal(t, "backend-agent", header.Get("User-Agent"))
+}
+
+func TestChartMetaLocation(t *testing.T) {
+	m := &ChartMeta{ExchangeTimezoneName: "Europe/London", Timezone: "GMT"}
+	assert.Equal(t, "Europe/London", m.Location().String())
+
+	m = &ChartMeta{Timezone: "EST", Gmtoffset: -18000}
+	_, offset := time.Unix(0, 0).In(m.Location()).Zone()
+	assert.Equal(t, -18000, offset)
+}
+
+func TestRemoteErrorDescription(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"chart":{"result":null,"error":{"code":"Not Found","description":"No data found, symbol may be delisted"}}}`))
+	}))
+	defer server.Close()
+
+	err := newTestBackend(t, server).Call("/v8/finance/chart/BAD", nil, nil, &struct{}{})
+	remoteErr, ok := err.(*RemoteError)
+	assert.True(t, ok)
+	assert.Equal(t, "Not Found", remoteErr.Code)
+	assert.Equal(t, "No data found, symbol may be delisted", remoteErr.Description)
+	assert.Contains(t, err.Error(), "No data found, symbol may be delisted")
+}
+
+func TestRemoteErrorRawBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid Cookie"))
+	}))
+	defer server.Close()
+
+	err := newTestBackend(t, server).Call("/v7/finance/quote", nil, nil, &struct{}{})
+	remoteErr, ok := err.(*RemoteError)
+	assert.True(t, ok)
+	assert.Empty(t, remoteErr.Code)
+	assert.Equal(t, "status: 400, detail: error response recieved from upstream api, body: Invalid Cookie", err.Error())
+}
+
+func TestRemoteErrorTruncatedBody(t *testing.T) {
+	// The multibyte rune straddles the cut.
+	body := strings.Repeat("a", maxErrorBodyLen-1) + "é" + "tail"
+	err := &RemoteError{StatusCode: http.StatusBadGateway, Msg: "bad gateway", Body: body}
+	assert.True(t, utf8.ValidString(err.Error()))
+	assert.True(t, strings.HasSuffix(err.Error(), strings.Repeat("a", maxErrorBodyLen-1)+"..."))
+}
+
+func TestSentinelErrors(t *testing.T) {
+	tests := []struct {
+		err  error
+		want []error
+	}{
+		{&RemoteError{StatusCode: http.StatusNotFound}, []error{ErrNotFound}},
+		{&RemoteError{StatusCode: http.StatusTooManyRequests}, []error{ErrRateLimited}},
+		{&RemoteError{StatusCode: http.StatusForbidden}, []error{ErrUnauthorized}},
+		{&RemoteError{StatusCode: http.StatusUnauthorized, Code: "Unauthorized", Description: "Invalid Crumb"}, []error{ErrInvalidCrumb, ErrUnauthorized}},
+		{CreateRemoteError(&YfinError{Code: "Not Found", Description: "Quote not found for symbol: BAD"}), []error{ErrNotFound}},
+	}
+	all := []error{ErrNotFound, ErrRateLimited, ErrUnauthorized, ErrInvalidCrumb}
+	for _, tt := range tests {
+		for _, sentinel := range all {
+			want := false
+			for _, w := range tt.want {
+				want = want || w == sentinel
+			}
+			assert.Equal(t, want, errors.Is(tt.err, sentinel), "%v is %v", tt.err, sentinel)
+		}
+	}
+}
+
+func TestCacheResponses(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"n":1}`))
+	}))
+	defer server.Close()
+
+	SetCache(NewMemoryCache(10))
+	SetCacheTTL("/v8/finance/chart", 0)
+	defer SetCache(nil)
+	defer SetCacheTTL("/v8/finance/chart", DefaultCacheTTL)
+
+	backend := newTestBackend(t, server)
+	for i := 0; i < 2; i++ {
+		v := struct{ N int }{}
+		assert.Nil(t, backend.Call("/v7/finance/quote", nil, nil, &v))
+		assert.Equal(t, 1, v.N)
+	}
+	assert.Equal(t, 1, calls)
+
+	for i := 0; i < 2; i++ {
+		assert.Nil(t, backend.Call("/v8/finance/chart/AAPL", nil, nil, &struct{}{}))
+	}
+	assert.Equal(t, 3, calls)
+}
+
+func TestMemoryCache(t *testing.T) {
+	c := NewMemoryCache(2)
+
+	c.Set("a", []byte("a"), time.Minute)
+	c.Set("b", []byte("b"), time.Minute)
+	c.Get("a")
+	c.Set("c", []byte("c"), time.Minute)
+
+	_, ok := c.Get("b")
+	assert.False(t, ok, "least recently used entry is evicted")
+	body, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("a"), body)
+
+	c.Set("d", []byte("d"), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	_, ok = c.Get("d")
+	assert.False(t, ok, "expired entry is not returned")
+}
+
+func TestDeduplicateConcurrentRequests(t *testing.T) {
+	var calls int32
+	arrived := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(arrived)
+		}
+		<-release
+		w.Write([]byte(`{"n":1}`))
+	}))
+	defer server.Close()
+
+	backend := newTestBackend(t, server)
+
+	var wg sync.WaitGroup
+	results := make([]int, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v := struct{ N int }{}
+			assert.Nil(t, backend.Call("/v7/finance/quote", nil, nil, &v))
+			results[i] = v.N
+		}(i)
+	}
+
+	<-arrived
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, []int{1, 1, 1, 1, 1}, results)
+}
+
+func TestSharedRequestOutlivesCaller(t *testing.T) {
+	var calls int32
+	arrived := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(arrived)
+		}
+		<-release
+		w.Write([]byte(`{"n":1}`))
+	}))
+	defer server.Close()
+
+	backend := newTestBackend(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := backend.CallRaw("/v7/finance/quote", nil, ctx)
+		first <- err
+	}()
+	<-arrived
+
+	second := make(chan []byte)
+	go func() {
+		body, err := backend.CallRaw("/v7/finance/quote", nil, context.Background())
+		assert.Nil(t, err)
+		second <- body
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, <-first, context.Canceled)
+
+	close(release)
+	assert.Equal(t, `{"n":1}`, string(<-second))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// Requests through distinct backends aren't shared.
+	other := newTestBackend(t, server)
+	var wg sync.WaitGroup
+	for _, b := range []*BackendConfiguration{backend, other} {
+		wg.Add(1)
+		go func(b *BackendConfiguration) {
+			defer wg.Done()
+			_, err := b.CallRaw("/v7/finance/quote", url.Values{"n": {"2"}}, context.Background())
+			assert.Nil(t, err)
+		}(b)
+	}
+	wg.Wait()
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestSetProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.Write([]byte(`{}`))
+	}))
+	defer proxy.Close()
+
+	assert.Nil(t, SetProxy(proxy.URL))
+	defer SetProxy("")
+
+	jar := httpClient.Jar
+	backend := &BackendConfiguration{Type: BATSBackend, URL: "http://finance.invalid", HTTPClient: httpClient}
+	assert.Nil(t, backend.Call("/v7/finance/quote", nil, nil, &struct{}{}))
+	assert.Equal(t, "http://finance.invalid/v7/finance/quote", proxied)
+	assert.Equal(t, jar, httpClient.Jar)
+
+	assert.NotNil(t, SetProxy("ftp://proxy.invalid"))
+}
+
+func TestSetTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	jar := httpClient.Jar
+	backend := &BackendConfiguration{Type: BATSBackend, URL: server.URL, HTTPClient: httpClient}
+	assert.NotNil(t, backend.Call("/v7/finance/quote", nil, nil, &struct{}{}))
+
+	SetTLSConfig(&tls.Config{InsecureSkipVerify: true})
+	defer SetTLSConfig(nil)
+
+	assert.Nil(t, backend.Call("/v7/finance/quote", nil, nil, &struct{}{}))
+	assert.Equal(t, jar, httpClient.Jar)
+}
+
+func TestBackendTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	backend := newTestBackend(t, server)
+	backend.Timeout = 10 * time.Millisecond
+
+	start := time.Now()
+	err := backend.Call("/v7/finance/quote", nil, nil, &struct{}{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestQuoteAssetClass(t *testing.T) {
+	tests := []struct {
+		quoteType QuoteType
+		want      QuoteType
+	}{
+		{"EQUITY", QuoteTypeEquity},
+		{"Equity", QuoteTypeEquity},
+		{" etf ", QuoteTypeETF},
+		{"Mutual Fund", QuoteTypeMutualFund},
+		{"cryptocurrency", QuoteTypeCryptoPair},
+	}
+	for _, tt := range tests {
+		q := &Quote{QuoteType: tt.quoteType}
+		assert.Equal(t, tt.want, q.AssetClass(), tt.quoteType)
+	}
+
+	q := &Quote{QuoteType: "Equity"}
+	assert.True(t, q.IsEquity())
+	assert.False(t, q.IsETF())
+	assert.True(t, (&Quote{QuoteType: "Currency"}).IsForexPair())
+}
+
+func TestQuotePrices(t *testing.T) {
+	q := &Quote{CurrencyID: "GBp", RegularMarketPrice: 1250, Bid: 1249, Ask: 1251}
+
+	prices := q.Prices()
+	assert.Equal(t, Money{Amount: 1250, Currency: "GBp"}, prices.RegularMarketPrice)
+	assert.True(t, prices.Bid.IsMinorUnit())
+	assert.Equal(t, Money{Amount: 12.51, Currency: "GBP"}, prices.Ask.Major())
+
+	usd := (&Quote{CurrencyID: "USD", RegularMarketPrice: 150}).Prices().RegularMarketPrice
+	assert.False(t, usd.IsMinorUnit())
+	assert.Equal(t, usd, usd.Major())
+}
+
+func TestQuoteRangesAndAverages(t *testing.T) {
+	var q Quote
+	err := json.Unmarshal([]byte(`{
+		"fiftyTwoWeekLow": 124.17,
+		"fiftyTwoWeekHigh": 199.62,
+		"fiftyTwoWeekLowChange": 60.5,
+		"fiftyTwoWeekLowChangePercent": 0.4872,
+		"fiftyTwoWeekHighChange": -14.95,
+		"fiftyTwoWeekHighChangePercent": -0.0749,
+		"fiftyTwoWeekRange": "124.17 - 199.62",
+		"fiftyTwoWeekChangePercent": 22.5,
+		"fiftyDayAverage": 180.1,
+		"fiftyDayAverageChange": 4.57,
+		"fiftyDayAverageChangePercent": 0.0254,
+		"twoHundredDayAverage": 170.2,
+		"twoHundredDayAverageChange": 14.47,
+		"twoHundredDayAverageChangePercent": 0.085
+	}`), &q)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.4872, q.FiftyTwoWeekLowChangePercent)
+	assert.Equal(t, -0.0749, q.FiftyTwoWeekHighChangePercent)
+	assert.Equal(t, "124.17 - 199.62", q.FiftyTwoWeekRange)
+	assert.Equal(t, 22.5, q.FiftyTwoWeekChangePercent)
+	assert.Equal(t, 0.0254, q.FiftyDayAverageChangePercent)
+	assert.Equal(t, 0.085, q.TwoHundredDayAverageChangePercent)
+}
+
+func TestQuoteSource(t *testing.T) {
+	var q Quote
+	err := json.Unmarshal([]byte(`{
+		"regularMarketTime": 1718395200,
+		"exchangeDataDelayedBy": 15,
+		"sourceInterval": 15,
+		"quoteSourceName": "Delayed Quote"
+	}`), &q)
+	assert.Nil(t, err)
+	assert.Equal(t, 1718395200, q.RegularMarketTime)
+	assert.Equal(t, 15, q.SourceInterval)
+	assert.Equal(t, "Delayed Quote", q.QuoteSource)
+	assert.True(t, q.IsDelayed())
+	assert.Equal(t, 15*time.Minute, q.Delay())
+
+	q = Quote{QuoteSource: "Nasdaq Real Time Price"}
+	assert.False(t, q.IsDelayed())
+	assert.Equal(t, time.Duration(0), q.Delay())
+}
+
+func TestQuoteExtendedHours(t *testing.T) {
+	var q Quote
+	err := json.Unmarshal([]byte(`{
+		"preMarketPrice": 151.2,
+		"preMarketChange": 1.2,
+		"preMarketChangePercent": 0.8,
+		"preMarketTime": 1700046000,
+		"postMarketPrice": 150.5,
+		"postMarketChange": -0.5,
+		"postMarketChangePercent": -0.33,
+		"postMarketTime": "2023-11-15T21:30:00Z"
+	}`), &q)
+	assert.Nil(t, err)
+	assert.Equal(t, 151.2, q.PreMarketPrice)
+	assert.Equal(t, 1700046000, q.PreMarketTime.Unix())
+	assert.Equal(t, -0.33, q.PostMarketChangePercent)
+	assert.Equal(t, 1700083800, q.PostMarketTime.Unix())
+
+	q = Quote{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"regularMarketPrice": 150}`), &q))
+	assert.Nil(t, q.PreMarketTime)
+	assert.Nil(t, q.PostMarketTime)
+}
+
+func TestQuoteDepth(t *testing.T) {
+	var equity Quote
+	err := json.Unmarshal([]byte(`{"quoteType":"EQUITY","bid":150.1,"ask":150.2,"bidSize":8,"askSize":12}`), &equity)
+	assert.Nil(t, err)
+	assert.Equal(t, 8, equity.BidSize)
+	assert.Equal(t, 12, equity.AskSize)
+
+	// Mutual funds don't report a bid or ask.
+	var fund Quote
+	err = json.Unmarshal([]byte(`{"quoteType":"MUTUALFUND","regularMarketPrice":25.3}`), &fund)
+	assert.Nil(t, err)
+	assert.Zero(t, fund.BidSize)
+	assert.Zero(t, fund.AskSize)
+}
+
+func TestCallRaw(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		if r.URL.Path == "/v1/finance/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"finance":{"result":null,"error":{"code":"Not Found","description":"HTTP 404 Not Found"}}}`))
+			return
+		}
+		w.Write([]byte("date,close\n2024-01-02,185.64\n"))
+	}))
+	defer server.Close()
+
+	b := newTestBackend(t, server)
+	body, err := b.CallRaw("/v1/finance/download", url.Values{"symbol": {"AAPL"}}, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "date,close\n2024-01-02,185.64\n", string(body))
+	assert.Equal(t, "AAPL", query.Get("symbol"))
+	assert.Equal(t, "test-crumb", query.Get("crumb"))
+
+	_, err = b.CallRaw("v1/finance/missing", nil, context.TODO())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestCallRawCopy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("date,close\n"))
+	}))
+	defer server.Close()
+
+	SetCache(NewMemoryCache(10))
+	defer SetCache(nil)
+
+	b := newTestBackend(t, server)
+	body, err := b.CallRaw("/v1/finance/download", nil, context.Background())
+	assert.Nil(t, err)
+	copy(body, "XXXX")
+
+	// The cached response is left untouched.
+	body, err = b.CallRaw("/v1/finance/download", nil, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "date,close\n", string(body))
+}
+
+func TestAppendLocale(t *testing.T) {
+	defer SetRegion("")
+	defer SetLang("")
+
+	values := &form.Values{}
+	AppendLocale(values)
+	assert.True(t, values.Empty())
+
+	SetRegion("GB")
+	SetLang("en-GB")
+	assert.Equal(t, "GB", GetRegion())
+	values.Add("symbols", "VOD.L")
+	AppendLocale(values)
+	assert.Equal(t, "symbols=VOD.L&region=GB&lang=en-GB", values.Encode())
+}
+
+func TestSetBackendConcurrent(t *testing.T) {
+	defer SetBackend(YFinBackend, GetBackend(YFinBackend))
+
+	b := &BackendConfiguration{Type: YFinBackend, URL: "http://localhost"}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetBackend(YFinBackend, b)
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NotNil(t, GetBackend(YFinBackend))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, b, GetBackend(YFinBackend))
+}
+
+// roundTripFunc adapts a function to an http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestCrumbBootstrapContext(t *testing.T) {
+	SetCrumb("")
+
+	// The crumb endpoints never respond.
+	b := &BackendConfiguration{
+		Type: YFinBackend,
+		URL:  "http://localhost",
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		})},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := b.Call("/v7/finance/quote", nil, &ctx, &struct{}{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestCrumbFetchedOnce(t *testing.T) {
+	const n = 10
+	SetCrumb("")
+	t.Cleanup(func() { SetCrumb("") })
+
+	var crumbs int32
+	started := make(chan struct{}, n)
+	release := make(chan struct{})
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body := ""
+		if req.URL.String() == yahooCrumbURL {
+			atomic.AddInt32(&crumbs, 1)
+			started <- struct{}{}
+			<-release
+			body = "shared-crumb"
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})}
+
+	var wg sync.WaitGroup
+	results := make([]string, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = getCrumb(context.Background(), client)
+		}(i)
+	}
+
+	// A waiter gives up with its own context
+	// while the fetch is still in flight.
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := getCrumb(ctx, client)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	wg.Wait()
+	for _, crumb := range results {
+		assert.Equal(t, "shared-crumb", crumb)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&crumbs))
+}
+
+func TestRefreshRejectedCrumb(t *testing.T) {
+	SetCrumb("stale-crumb")
+	t.Cleanup(func() { SetCrumb("") })
+
+	var sessions, crumbs int
+	var sent []string
+	jar, _ := cookiejar.New(nil)
+	u, _ := url.Parse(yahooSessionURL)
+	jar.SetCookies(u, []*http.Cookie{{Name: "A3", Value: "expired"}})
+
+	b := &BackendConfiguration{
+		Type: YFinBackend,
+		URL:  "http://localhost",
+		HTTPClient: &http.Client{Jar: jar, Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			status, body := http.StatusOK, "{}"
+			switch req.URL.String() {
+			case yahooSessionURL:
+				sessions++
+			case yahooCrumbURL:
+				crumbs++
+				body = "fresh-crumb"
+			default:
+				sent = append(sent, req.URL.Query().Get("crumb"))
+				if len(sent) == 1 {
+					status, body = http.StatusUnauthorized, "Unauthorized"
+				}
+			}
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		})},
+	}
+
+	assert.Nil(t, b.Call("/v7/finance/quote", nil, nil, &struct{}{}))
+	assert.Equal(t, []string{"stale-crumb", "fresh-crumb"}, sent)
+	assert.Equal(t, 1, sessions)
+	assert.Equal(t, 1, crumbs)
+}
+
+func TestRefreshRejectedCrumbError(t *testing.T) {
+	SetCrumb("stale-crumb")
+	t.Cleanup(func() { SetCrumb("") })
+
+	var calls int
+	b := &BackendConfiguration{
+		Type: YFinBackend,
+		URL:  "http://localhost",
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			status := http.StatusOK
+			if req.URL.Host == "localhost" {
+				calls++
+				status = http.StatusUnauthorized
+				if calls > 1 {
+					status = http.StatusNotFound
+				}
+			}
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(strings.NewReader("fresh-crumb")),
+				Request:    req,
+			}, nil
+		})},
+	}
+
+	// The error of the retried request is returned.
+	err := b.Call("/v7/finance/quote", nil, nil, &struct{}{})
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, 2, calls)
+}
+
+func TestStructuredLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	buf := &bytes.Buffer{}
+	SetLogger(slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer SetLogger(nil)
+
+	b := newTestBackend(t, server)
+	b.MaxRetries = 1
+	b.RetryBackoff = func(int) time.Duration { return 0 }
+	assert.NotNil(t, b.Call("/v7/finance/quote", nil, nil, &struct{}{}))
+
+	var records []map[string]interface{}
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var r map[string]interface{}
+		assert.Nil(t, dec.Decode(&r))
+		records = append(records, r)
+	}
+
+	msgs := []string{}
+	for _, r := range records {
+		msgs = append(msgs, r["msg"].(string))
+	}
+	assert.Equal(t, []string{"Requesting", "API error", "Retrying request", "API error"}, msgs)
+
+	last := records[len(records)-1]
+	assert.Equal(t, "GET", last["method"])
+	assert.Equal(t, strings.TrimPrefix(server.URL, "http://")+"/v7/finance/quote", last["url"])
+	assert.Equal(t, 429.0, last["status"])
+	assert.Equal(t, 1.0, last["retry_count"])
+	assert.Contains(t, last, "duration_ms")
+}
+
+func TestPrintfLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	lines := &printfRecorder{}
+	logger, level := Logger, LogLevel
+	Logger, LogLevel = lines, 1
+	defer func() { Logger, LogLevel = logger, level }()
+
+	assert.NotNil(t, newTestBackend(t, server).Call("/v7/finance/quote", nil, nil, &struct{}{}))
+	assert.Len(t, lines.lines, 1)
+	assert.True(t, strings.HasPrefix(lines.lines[0], "API error method=GET"))
+	assert.Contains(t, lines.lines[0], " status=404 ")
+}
+
+// printfRecorder records the lines logged through it.
+type printfRecorder struct {
+	lines []string
+}
+
+func (p *printfRecorder) Printf(format string, v ...interface{}) {
+	p.lines = append(p.lines, fmt.Sprintf(format, v...))
+}
+
+func TestHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var paths []string
+	var statuses []int
+	SetRequestHook(func(req *http.Request) { paths = append(paths, req.URL.Path) })
+	SetResponseHook(func(res *http.Response, d time.Duration) {
+		assert.True(t, d >= 0)
+		if res == nil {
+			statuses = append(statuses, 0)
+			return
+		}
+		statuses = append(statuses, res.StatusCode)
+	})
+	defer SetRequestHook(nil)
+	defer SetResponseHook(nil)
+
+	b := newTestBackend(t, server)
+	b.MaxRetries = 1
+	b.RetryBackoff = func(int) time.Duration { return 0 }
+	assert.Nil(t, b.Call("/ok", nil, nil, &struct{}{}))
+	assert.NotNil(t, b.Call("/fail", nil, nil, &struct{}{}))
+
+	b.URL = "http://127.0.0.1:1"
+	assert.NotNil(t, b.Call("/down", nil, nil, &struct{}{}))
+
+	assert.Equal(t, []string{"/ok", "/fail", "/fail", "/down", "/down"}, paths)
+	assert.Equal(t, []int{200, 500, 500, 0, 0}, statuses)
+}
+
+func TestHooksUnsent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var paths []string
+	var statuses []int
+	SetRequestHook(func(req *http.Request) { paths = append(paths, req.URL.Path) })
+	SetResponseHook(func(res *http.Response, d time.Duration) {
+		if res == nil {
+			statuses = append(statuses, 0)
+			return
+		}
+		statuses = append(statuses, res.StatusCode)
+	})
+	defer SetRequestHook(nil)
+	defer SetResponseHook(nil)
+
+	// The breaker opens after the first failure.
+	b := newTestBackend(t, server)
+	b.Breaker = NewCircuitBreaker(1, time.Hour)
+	assert.NotNil(t, b.Call("/fail", nil, nil, &struct{}{}))
+	assert.ErrorIs(t, b.Call("/open", nil, nil, &struct{}{}), ErrCircuitOpen)
+
+	// The rate limit wait would outlast the deadline.
+	SetRateLimit(0.001, 1)
+	defer SetRateLimit(0, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	b = newTestBackend(t, server)
+	assert.NotNil(t, b.Call("/fail", nil, &ctx, &struct{}{}))
+	assert.NotNil(t, b.Call("/limited", nil, &ctx, &struct{}{}))
+	SetRateLimit(0, 0)
+
+	// No crumb can be fetched.
+	SetCrumb("")
+	b = &BackendConfiguration{
+		Type: YFinBackend,
+		URL:  "http://localhost",
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusForbidden, Body: http.NoBody, Header: http.Header{}}, nil
+		})},
+	}
+	assert.NotNil(t, b.Call("/nocrumb", nil, nil, &struct{}{}))
+
+	assert.Equal(t, []string{"/fail", "/open", "/fail", "/limited", "/nocrumb"}, paths)
+	assert.Equal(t, []int{500, 0, 500, 0, 0}, statuses)
+}
+
+func TestTracing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v8/finance/chart/BAD" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+
+	b := newTestBackend(t, server)
+	body := &form.Values{}
+	body.Add("symbols", "AAPL,MSFT,GOOG")
+	assert.Nil(t, b.Call("/v7/finance/quote", body, &ctx, &struct{}{}))
+	assert.NotNil(t, b.Call("/v8/finance/chart/BAD", nil, &ctx, &struct{}{}))
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 3)
+
+	attrs := func(s sdktrace.ReadOnlySpan) map[string]interface{} {
+		m := map[string]interface{}{}
+		for _, kv := range s.Attributes() {
+			m[string(kv.Key)] = kv.Value.AsInterface()
+		}
+		return m
+	}
+
+	quote := spans[0]
+	assert.Equal(t, parent.SpanContext().SpanID(), quote.Parent().SpanID())
+	assert.Equal(t, "/v7/finance/quote", attrs(quote)["url.path"])
+	assert.Equal(t, int64(3), attrs(quote)["finance.symbol_count"])
+	assert.Equal(t, int64(200), attrs(quote)["http.response.status_code"])
+	assert.Equal(t, codes.Unset, quote.Status().Code)
+
+	chart := spans[1]
+	assert.Equal(t, int64(1), attrs(chart)["finance.symbol_count"])
+	assert.Equal(t, int64(404), attrs(chart)["http.response.status_code"])
+	assert.Equal(t, codes.Error, chart.Status().Code)
+
+	// Without a span in the context, nothing is traced.
+	recorder = tracetest.NewSpanRecorder()
+	provider.RegisterSpanProcessor(recorder)
+	assert.Nil(t, b.Call("/v7/finance/quote", body, nil, &struct{}{}))
+	assert.Empty(t, recorder.Ended())
+}
+
+func TestTracingCacheHit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	SetCache(NewMemoryCache(10))
+	defer SetCache(nil)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+
+	b := newTestBackend(t, server)
+	for i := 0; i < 2; i++ {
+		assert.Nil(t, b.Call("/v7/finance/quote", nil, &ctx, &struct{}{}))
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 3)
+
+	hit := map[string]interface{}{}
+	for _, kv := range spans[1].Attributes() {
+		hit[string(kv.Key)] = kv.Value.AsInterface()
+	}
+	assert.Equal(t, true, hit["finance.cache_hit"])
+	assert.NotContains(t, hit, "http.response.status_code")
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
+
+func TestPing(t *testing.T) {
+	var symbols []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		symbols = append(symbols, r.URL.Query().Get("symbols"))
+		w.Write([]byte(`{"quoteResponse":{"result":[]}}`))
+	}))
+	defer server.Close()
+
+	previous := GetBackend(YFinBackend)
+	defer SetBackend(YFinBackend, previous)
+	SetBackend(YFinBackend, newTestBackend(t, server))
+
+	assert.Nil(t, Ping(context.Background()))
+	assert.Equal(t, []string{"SPY"}, symbols)
+}
+
+func TestPingUnauthorized(t *testing.T) {
+	SetCrumb("")
+
+	previous := GetBackend(YFinBackend)
+	defer SetBackend(YFinBackend, previous)
+	SetBackend(YFinBackend, &BackendConfiguration{
+		Type: YFinBackend,
+		URL:  "http://localhost",
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusForbidden,
+				Body:       io.NopCloser(strings.NewReader("Forbidden")),
+				Request:    req,
+			}, nil
+		})},
+	})
+
+	err := Ping(context.Background())
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.EqualError(t, err, "finance: unauthorized: cannot get yahoo crumb: status: 403, detail: cannot fetch yahoo crumb, body: Forbidden")
+}
@@ -0,0 +1,77 @@
+package financetest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/form"
+)
+
+// MockBackend is a finance.Backend serving canned responses.
+// Install it with finance.SetBackend, or set it as the B
+// field of a package client.
+type MockBackend struct {
+	// Err is returned for paths without a response.
+	// Defaults to a not found RemoteError.
+	Err error
+
+	mu        sync.Mutex
+	responses map[string][]byte
+	calls     []string
+}
+
+// NewMockBackend returns a backend serving the given response
+// bodies by request path, such as "v7/finance/quote".
+// Query values aren't considered when matching.
+func NewMockBackend(responses map[string][]byte) *MockBackend {
+	m := &MockBackend{responses: map[string][]byte{}}
+	for path, body := range responses {
+		m.responses[normalize(path)] = body
+	}
+	return m
+}
+
+// Call unmarshals the response for path into v.
+func (m *MockBackend) Call(path string, body *form.Values, ctx *context.Context, v interface{}) error {
+	m.mu.Lock()
+	path = normalize(path)
+	m.calls = append(m.calls, path)
+	resp, ok := m.responses[path]
+	m.mu.Unlock()
+
+	if !ok {
+		if m.Err != nil {
+			return m.Err
+		}
+		return &finance.RemoteError{
+			Msg:        "no mock response for " + path,
+			StatusCode: http.StatusNotFound,
+		}
+	}
+
+	if v == nil {
+		return nil
+	}
+	return json.Unmarshal(resp, v)
+}
+
+// Calls returns the paths requested so far, in order.
+func (m *MockBackend) Calls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.calls...)
+}
+
+// normalize strips the leading slash and any
+// query from a path, so that paths match however
+// the packages spell them.
+func normalize(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	return strings.TrimPrefix(path, "/")
+}
@@ -0,0 +1,36 @@
+package financetest
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/quote"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockBackend(t *testing.T) {
+	b := NewMockBackend(map[string][]byte{
+		"v7/finance/quote": []byte(`{"quoteResponse":{"result":[{"symbol":"AAPL","regularMarketPrice":150}]}}`),
+	})
+	c := quote.Client{B: b}
+
+	iter := c.ListP(&quote.Params{Symbols: []string{"AAPL"}})
+	assert.True(t, iter.Next())
+	assert.Equal(t, 150.0, iter.Quote().RegularMarketPrice)
+	assert.Equal(t, []string{"v7/finance/quote"}, b.Calls())
+}
+
+func TestMockBackendUnmatched(t *testing.T) {
+	b := NewMockBackend(nil)
+
+	err := b.Call("/v8/finance/chart/AAPL", nil, nil, &struct{}{})
+	var remoteErr *finance.RemoteError
+	assert.True(t, errors.As(err, &remoteErr))
+	assert.Equal(t, http.StatusNotFound, remoteErr.StatusCode)
+	assert.ErrorIs(t, err, finance.ErrNotFound)
+
+	b.Err = errors.New("offline")
+	assert.EqualError(t, b.Call("v8/finance/chart/AAPL", nil, nil, nil), "offline")
+}
@@ -0,0 +1,155 @@
+package financetest
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RecordEnv is the environment variable that, when set,
+// makes recorders record live responses instead of replaying.
+const RecordEnv = "FINANCE_RECORD"
+
+// Recorder is an http.RoundTripper that records responses to golden
+// files in a directory and replays them, so that tests can run offline
+// and deterministically. Recordings are matched by method and url,
+// ignoring the crumb, which changes between sessions.
+type Recorder struct {
+	// Dir holds the golden files.
+	Dir string
+	// Record sends requests upstream and saves the responses,
+	// overwriting existing recordings. Otherwise responses are
+	// replayed and requests without a recording fail, or go
+	// to Fallback if set.
+	Record bool
+	// Transport sends requests while recording.
+	// Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+	// Fallback sends requests without a recording while replaying.
+	Fallback http.RoundTripper
+}
+
+// NewRecorder returns a recorder for dir that
+// records if RecordEnv is set and replays otherwise.
+func NewRecorder(dir string) *Recorder {
+	return &Recorder{Dir: dir, Record: os.Getenv(RecordEnv) != ""}
+}
+
+// Client returns an http client using the recorder, for
+// finance.SetHTTPClient or a backend configuration.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// recording is a golden file.
+type recording struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   string      `json:"body"`
+}
+
+// RoundTrip replays or records the response to a request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := recordingURL(req.URL)
+	path := filepath.Join(r.Dir, recordingName(req.Method, key))
+
+	if !r.Record {
+		data, err := ioutil.ReadFile(path)
+		if err != nil && r.Fallback != nil {
+			return r.Fallback.RoundTrip(req)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("financetest: no recording for %s %s, set %s=1 to record it", req.Method, key, RecordEnv)
+		}
+		var rec recording
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			Status:     fmt.Sprintf("%d %s", rec.Status, http.StatusText(rec.Status)),
+			StatusCode: rec.Status,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     rec.Header,
+			Body:       ioutil.NopCloser(strings.NewReader(rec.Body)),
+			Request:    req,
+		}, nil
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(recording{
+		Method: req.Method,
+		URL:    key,
+		Status: resp.StatusCode,
+		Header: resp.Header,
+		Body:   string(body),
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(r.Dir, 0755); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return nil, err
+	}
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// recordingURL returns the url a recording is matched by,
+// with the crumb removed and the query sorted.
+func recordingURL(u *url.URL) string {
+	query := u.Query()
+	query.Del("crumb")
+
+	key := *u
+	key.RawQuery = query.Encode()
+	return key.String()
+}
+
+// recordingName returns the golden file name for a request,
+// readable from its path and unique from a hash of its url.
+func recordingName(method, key string) string {
+	sum := sha1.Sum([]byte(method + " " + key))
+
+	name := key
+	if u, err := url.Parse(key); err == nil {
+		name = u.Host + u.Path
+	}
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		}
+		return '_'
+	}, name)
+
+	return method + "_" + name + "_" + hex.EncodeToString(sum[:4]) + ".json"
+}
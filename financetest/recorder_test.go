@@ -0,0 +1,63 @@
+package financetest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/quote"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"quoteResponse":{"result":[{"symbol":"AAPL","regularMarketPrice":150}]}}`)
+	}))
+	dir := t.TempDir()
+
+	get := func(r *Recorder) (*finance.Quote, error) {
+		c := quote.Client{B: &finance.BackendConfiguration{
+			Type:       finance.YFinBackend,
+			URL:        server.URL,
+			HTTPClient: r.Client(),
+		}}
+		iter := c.ListP(&quote.Params{Symbols: []string{"AAPL"}})
+		if !iter.Next() {
+			return nil, iter.Err()
+		}
+		return iter.Quote(), nil
+	}
+
+	finance.SetCrumb("live-crumb")
+	t.Cleanup(func() { finance.SetCrumb("") })
+	q, err := get(&Recorder{Dir: dir, Record: true})
+	assert.Nil(t, err)
+	assert.Equal(t, 150.0, q.RegularMarketPrice)
+	assert.Equal(t, 1, calls)
+
+	// Replays offline, even with a different crumb.
+	server.Close()
+	finance.SetCrumb("replay-crumb")
+	q, err = get(&Recorder{Dir: dir})
+	assert.Nil(t, err)
+	assert.Equal(t, 150.0, q.RegularMarketPrice)
+	assert.Equal(t, 1, calls)
+
+	_, err = get(&Recorder{Dir: t.TempDir()})
+	assert.ErrorContains(t, err, "no recording for GET")
+
+	// Falls back to the server for requests without a recording.
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"quoteResponse":{"result":[{"symbol":"AAPL","regularMarketPrice":151}]}}`)
+	}))
+	defer server.Close()
+	q, err = get(&Recorder{Dir: t.TempDir(), Fallback: http.DefaultTransport})
+	assert.Nil(t, err)
+	assert.Equal(t, 151.0, q.RegularMarketPrice)
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,28 @@
+// Package financetest provides utilities for testing code
+// that uses the finance packages without calling yahoo.
+package financetest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	finance "github.com/fijoyapp/finance-go"
+)
+
+// NewServerBackend starts a test server with handler and returns a
+// yfin backend pointing at it, with a crumb set so that requests skip
+// the crumb bootstrap. The server is closed and the crumb cleared
+// when the test finishes.
+func NewServerBackend(t testing.TB, handler http.Handler) *finance.BackendConfiguration {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	finance.SetCrumb("test-crumb")
+	t.Cleanup(func() { finance.SetCrumb("") })
+	return &finance.BackendConfiguration{
+		Type:       finance.YFinBackend,
+		URL:        server.URL,
+		HTTPClient: server.Client(),
+	}
+}
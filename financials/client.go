@@ -0,0 +1,183 @@
+package financials
+
+import (
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/datetime"
+	"github.com/fijoyapp/finance-go/quotesummary"
+)
+
+// Client is used to invoke financial statement APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and financial statement information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Symbol string `form:"-"`
+	// Frequency limits the statements to a single reporting
+	// period. Both annual and quarterly statements are
+	// returned if it is empty.
+	Frequency finance.StatementFrequency `form:"-"`
+}
+
+// statementModules are the annual and quarterly
+// quote summary modules for a kind of statement.
+type statementModules struct {
+	annual    string
+	quarterly string
+}
+
+var (
+	incomeStatement = statementModules{
+		quotesummary.ModuleIncomeStatementHistory,
+		quotesummary.ModuleIncomeStatementHistoryQuarterly,
+	}
+	balanceSheet = statementModules{
+		quotesummary.ModuleBalanceSheetHistory,
+		quotesummary.ModuleBalanceSheetHistoryQuarterly,
+	}
+	cashFlow = statementModules{
+		quotesummary.ModuleCashflowStatementHistory,
+		quotesummary.ModuleCashflowStatementHistoryQuarterly,
+	}
+)
+
+// GetIncomeStatement returns the annual and quarterly
+// income statements for a symbol.
+func GetIncomeStatement(symbol string) ([]finance.FinancialStatement, error) {
+	return GetIncomeStatementP(&Params{Symbol: symbol})
+}
+
+// GetIncomeStatementP returns income statements and requires
+// a params struct as an argument.
+func GetIncomeStatementP(params *Params) ([]finance.FinancialStatement, error) {
+	return getC().GetIncomeStatementP(params)
+}
+
+// GetIncomeStatementP returns income statements.
+func (c Client) GetIncomeStatementP(params *Params) ([]finance.FinancialStatement, error) {
+	return c.get(params, incomeStatement)
+}
+
+// GetBalanceSheet returns the annual and quarterly
+// balance sheets for a symbol.
+func GetBalanceSheet(symbol string) ([]finance.FinancialStatement, error) {
+	return GetBalanceSheetP(&Params{Symbol: symbol})
+}
+
+// GetBalanceSheetP returns balance sheets and requires
+// a params struct as an argument.
+func GetBalanceSheetP(params *Params) ([]finance.FinancialStatement, error) {
+	return getC().GetBalanceSheetP(params)
+}
+
+// GetBalanceSheetP returns balance sheets.
+func (c Client) GetBalanceSheetP(params *Params) ([]finance.FinancialStatement, error) {
+	return c.get(params, balanceSheet)
+}
+
+// GetCashFlow returns the annual and quarterly
+// cash flow statements for a symbol.
+func GetCashFlow(symbol string) ([]finance.FinancialStatement, error) {
+	return GetCashFlowP(&Params{Symbol: symbol})
+}
+
+// GetCashFlowP returns cash flow statements and requires
+// a params struct as an argument.
+func GetCashFlowP(params *Params) ([]finance.FinancialStatement, error) {
+	return getC().GetCashFlowP(params)
+}
+
+// GetCashFlowP returns cash flow statements.
+func (c Client) GetCashFlowP(params *Params) ([]finance.FinancialStatement, error) {
+	return c.get(params, cashFlow)
+}
+
+// get fetches the statement modules selected by the
+// params frequency and converts their statements.
+func (c Client) get(params *Params, modules statementModules) ([]finance.FinancialStatement, error) {
+	if params == nil || len(params.Symbol) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	var requested []string
+	if params.Frequency != finance.StatementFrequencyQuarterly {
+		requested = append(requested, modules.annual)
+	}
+	if params.Frequency != finance.StatementFrequencyAnnual {
+		requested = append(requested, modules.quarterly)
+	}
+
+	summary, err := quotesummary.Client{B: c.B}.GetP(&quotesummary.Params{
+		Params:  params.Params,
+		Symbol:  params.Symbol,
+		Modules: requested,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	annual, quarterly := histories(summary, modules)
+
+	var statements []finance.FinancialStatement
+	statements = appendStatements(statements, annual, finance.StatementFrequencyAnnual)
+	statements = appendStatements(statements, quarterly, finance.StatementFrequencyQuarterly)
+	return statements, nil
+}
+
+// histories returns the annual and quarterly statement
+// histories for a kind of statement from a quote summary.
+func histories(s *finance.QuoteSummary, modules statementModules) (annual, quarterly *finance.StatementHistory) {
+	switch modules {
+	case incomeStatement:
+		return s.IncomeStatementHistory, s.IncomeStatementHistoryQuarterly
+	case balanceSheet:
+		return s.BalanceSheetHistory, s.BalanceSheetHistoryQuarterly
+	case cashFlow:
+		return s.CashflowStatementHistory, s.CashflowStatementHistoryQuarterly
+	}
+	return nil, nil
+}
+
+// appendStatements converts the statements of a history
+// and appends them to the given list.
+func appendStatements(list []finance.FinancialStatement, h *finance.StatementHistory, freq finance.StatementFrequency) []finance.FinancialStatement {
+	if h == nil {
+		return list
+	}
+
+	for _, raw := range h.Statements {
+		statement := finance.FinancialStatement{
+			Frequency: freq,
+			LineItems: map[string]*float64{},
+		}
+
+		for name, v := range raw {
+			value, ok := v.(float64)
+			if !ok {
+				// Not reported.
+				continue
+			}
+
+			switch name {
+			case "endDate":
+				statement.EndDate = *datetime.FromUnix(int(value))
+			case "maxAge":
+				// Cache meta data, not a line item.
+			default:
+				statement.LineItems[name] = &value
+			}
+		}
+
+		list = append(list, statement)
+	}
+	return list
+}
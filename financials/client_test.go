@@ -0,0 +1,87 @@
+package financials
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetIncomeStatement(t *testing.T) {
+	var query url.Values
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"quoteSummary":{"result":[{
+			"incomeStatementHistory":{"incomeStatementHistory":[
+				{"maxAge":1,"endDate":{"raw":1695945600,"fmt":"2023-09-30"},"totalRevenue":{"raw":383285000000,"fmt":"383.29B"}}
+			],"maxAge":86400},
+			"incomeStatementHistoryQuarterly":{"incomeStatementHistory":[
+				{"maxAge":1,"endDate":{"raw":1703721600,"fmt":"2023-12-30"},"totalRevenue":{"raw":119575000000,"fmt":"119.58B"}}
+			],"maxAge":86400}
+		}]}}`)
+	}))
+	c := Client{B: backend}
+
+	statements, err := c.GetIncomeStatementP(&Params{Symbol: "AAPL"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "incomeStatementHistory,incomeStatementHistoryQuarterly", query.Get("modules"))
+	assert.Len(t, statements, 2)
+	assert.Equal(t, finance.StatementFrequencyAnnual, statements[0].Frequency)
+	assert.Equal(t, 1695945600, statements[0].EndDate.Unix())
+	assert.Equal(t, 383285000000.0, *statements[0].LineItems["totalRevenue"])
+	assert.Equal(t, finance.StatementFrequencyQuarterly, statements[1].Frequency)
+}
+
+func TestGetQuarterlyBalanceSheet(t *testing.T) {
+	var query url.Values
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"quoteSummary":{"result":[{
+			"balanceSheetHistoryQuarterly":{"balanceSheetStatements":[
+				{"endDate":{"raw":1703721600},"totalAssets":{"raw":353514000000}},
+				{"endDate":{"raw":1696032000},"totalAssets":{"raw":352583000000}}
+			]}
+		}]}}`)
+	}))
+	c := Client{B: backend}
+
+	statements, err := c.GetBalanceSheetP(&Params{
+		Symbol:    "AAPL",
+		Frequency: finance.StatementFrequencyQuarterly,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "balanceSheetHistoryQuarterly", query.Get("modules"))
+	assert.Len(t, statements, 2)
+	for _, s := range statements {
+		assert.Equal(t, finance.StatementFrequencyQuarterly, s.Frequency)
+	}
+}
+
+func TestNilParamsCashFlow(t *testing.T) {
+	statements, err := GetCashFlowP(nil)
+
+	assert.Nil(t, statements)
+	assert.Equal(t, "code: api-error, detail: missing function argument", err.Error())
+}
+
+func TestAppendStatementsMissingItems(t *testing.T) {
+	h := &finance.StatementHistory{Statements: []map[string]interface{}{
+		{"endDate": 1514678400.0, "maxAge": 1.0, "totalRevenue": 229234000000.0, "netIncome": nil},
+	}}
+
+	statements := appendStatements(nil, h, finance.StatementFrequencyAnnual)
+
+	assert.Len(t, statements, 1)
+	assert.Equal(t, 1514678400, statements[0].EndDate.Unix())
+	assert.Equal(t, 229234000000.0, *statements[0].LineItems["totalRevenue"])
+	_, ok := statements[0].LineItems["netIncome"]
+	assert.False(t, ok)
+	_, ok = statements[0].LineItems["maxAge"]
+	assert.False(t, ok)
+}
@@ -1,9 +1,14 @@
 package forex
 
 import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
 	"testing"
 
 	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/financetest"
 	tests "github.com/fijoyapp/finance-go/testing"
 	"github.com/stretchr/testify/assert"
 )
@@ -56,3 +61,56 @@ func TestGetBadForexPair(t *testing.T) {
 	assert.Nil(t, q)
 	assert.Nil(t, err)
 }
+
+// newTestClient returns a client for a server
+// quoting the given forex pair prices.
+func newTestClient(t *testing.T, prices map[string]float64) (Client, *int32) {
+	var calls int32
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		results := []string{}
+		for _, s := range strings.Split(r.URL.Query().Get("symbols"), ",") {
+			if price, ok := prices[s]; ok {
+				results = append(results, fmt.Sprintf(`{"symbol":%q,"regularMarketPrice":%v}`, s, price))
+			}
+		}
+		fmt.Fprintf(w, `{"quoteResponse":{"result":[%s]}}`, strings.Join(results, ","))
+	}))
+	return Client{B: backend}, &calls
+}
+
+func TestConvert(t *testing.T) {
+	c, calls := newTestClient(t, map[string]float64{"EURUSD=X": 1.25, "USDJPY=X": 150})
+
+	amount, err := c.Convert(10, "EUR", "USD")
+	assert.Nil(t, err)
+	assert.Equal(t, 12.5, amount)
+
+	amount, err = c.Convert(300, "jpy", "usd")
+	assert.Nil(t, err)
+	assert.Equal(t, 2.0, amount)
+
+	amount, err = c.Convert(10, "USD", "USD")
+	assert.Nil(t, err)
+	assert.Equal(t, 10.0, amount)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+
+	_, err = c.Convert(10, "USD", "XXX")
+	assert.Equal(t, ErrNoRate, err)
+}
+
+func TestRates(t *testing.T) {
+	c, calls := newTestClient(t, map[string]float64{"USDEUR=X": 0.8, "JPYUSD=X": 0.005})
+
+	rates, err := c.Rates("usd", []string{"EUR", "JPY", "USD", "XXX"})
+	assert.Equal(t, &UnavailableError{Unavailable: []string{"XXX"}}, err)
+	assert.EqualError(t, err, "code: remote-error, detail: no forex rates for XXX")
+	assert.Equal(t, map[string]float64{"EUR": 0.8, "JPY": 200, "USD": 1}, rates)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestRatesNilParams(t *testing.T) {
+	rates, err := RatesP(nil)
+	assert.Nil(t, rates)
+	assert.EqualError(t, err, "code: api-error, detail: missing function argument")
+}
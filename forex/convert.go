@@ -0,0 +1,152 @@
+package forex
+
+import (
+	"errors"
+	"strings"
+
+	finance "github.com/fijoyapp/finance-go"
+)
+
+// ErrNoRate is returned when yahoo quotes no
+// rate for a currency pair in either direction.
+var ErrNoRate = errors.New("no forex rate for currency pair")
+
+// UnavailableError is returned by Rates when yahoo
+// quotes no rate for some of the requested currencies.
+type UnavailableError struct {
+	// Unavailable are the quote currencies without a rate.
+	Unavailable []string
+}
+
+// Error returns the unavailable currencies.
+func (e *UnavailableError) Error() string {
+	return finance.CreateRemoteErrorS("no forex rates for " + strings.Join(e.Unavailable, ",")).Error()
+}
+
+// Convert converts an amount between currencies
+// given as ISO codes, e.g. "EUR" and "USD".
+func Convert(amount float64, from, to string) (float64, error) {
+	return getC().Convert(amount, from, to)
+}
+
+// Convert converts an amount between currencies given as ISO codes,
+// using the latest price of the pair. If yahoo only quotes the
+// reverse pair, its inverse is used instead.
+func (c Client) Convert(amount float64, from, to string) (float64, error) {
+	if from == "" || to == "" {
+		return 0, finance.CreateArgumentError()
+	}
+
+	rates, err := c.rates(finance.Params{}, from, []string{to})
+	if err != nil {
+		return 0, err
+	}
+
+	rate, ok := rates[strings.ToUpper(to)]
+	if !ok {
+		return 0, ErrNoRate
+	}
+	return amount * rate, nil
+}
+
+// RatesParams carries a context and currencies information.
+type RatesParams struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	// Base is the currency rates are quoted from.
+	Base string `form:"-"`
+	// Quotes are the currencies rates are quoted to.
+	Quotes []string `form:"-"`
+}
+
+// Rates returns the rates from a base currency
+// to each of the quote currencies.
+func Rates(base string, quotes []string) (map[string]float64, error) {
+	return RatesP(&RatesParams{Base: base, Quotes: quotes})
+}
+
+// RatesP returns the rates from a base currency to each of
+// the quote currencies and requires a params struct as an argument.
+func RatesP(params *RatesParams) (map[string]float64, error) {
+	return getC().RatesP(params)
+}
+
+// Rates returns the rates from a base currency
+// to each of the quote currencies.
+func (c Client) Rates(base string, quotes []string) (map[string]float64, error) {
+	return c.RatesP(&RatesParams{Base: base, Quotes: quotes})
+}
+
+// RatesP returns the rates from a base currency to each of the quote
+// currencies, keyed by quote currency, in a single request. Inverse
+// pairs are used when yahoo only quotes the reverse pair. If some
+// rates are missing, the rates found are returned along with an
+// *UnavailableError listing the missing currencies.
+func (c Client) RatesP(params *RatesParams) (map[string]float64, error) {
+	if params == nil || params.Base == "" || len(params.Quotes) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	rates, err := c.rates(params.Params, params.Base, params.Quotes)
+	if err != nil {
+		return nil, err
+	}
+
+	unavailable := []string{}
+	for _, q := range params.Quotes {
+		if _, ok := rates[strings.ToUpper(q)]; !ok {
+			unavailable = append(unavailable, q)
+		}
+	}
+	if len(unavailable) > 0 {
+		return rates, &UnavailableError{Unavailable: unavailable}
+	}
+	return rates, nil
+}
+
+// rates fetches the rates from base to quotes, keyed by the
+// upper case quote currency. Missing rates are left out.
+func (c Client) rates(params finance.Params, base string, quotes []string) (map[string]float64, error) {
+	base = strings.ToUpper(base)
+	rates := map[string]float64{}
+
+	symbols := []string{}
+	for _, q := range quotes {
+		q = strings.ToUpper(q)
+		if q == base {
+			rates[q] = 1
+			continue
+		}
+		symbols = append(symbols, pairSymbol(base, q), pairSymbol(q, base))
+	}
+	if len(symbols) == 0 {
+		return rates, nil
+	}
+
+	i := c.ListP(&Params{Params: params, Symbols: symbols})
+	prices := map[string]float64{}
+	for i.Next() {
+		p := i.ForexPair()
+		prices[strings.ToUpper(p.Symbol)] = p.RegularMarketPrice
+	}
+	if i.Err() != nil {
+		return nil, i.Err()
+	}
+
+	for _, q := range quotes {
+		q = strings.ToUpper(q)
+		if rate := prices[pairSymbol(base, q)]; rate > 0 {
+			rates[q] = rate
+		} else if rate := prices[pairSymbol(q, base)]; rate > 0 {
+			rates[q] = 1 / rate
+		}
+	}
+	return rates, nil
+}
+
+// pairSymbol returns the yahoo symbol of a currency pair.
+func pairSymbol(from, to string) string {
+	return from + to + "=X"
+}
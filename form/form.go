@@ -518,6 +518,27 @@ func (f *Values) Empty() bool {
 	return len(f.values) == 0
 }
 
+// Merge adds the values of other, replacing
+// any existing values for the same keys.
+func (f *Values) Merge(other *Values) {
+	if other == nil {
+		return
+	}
+
+	replaced := map[string]bool{}
+	for _, v := range other.values {
+		replaced[v.Key] = true
+	}
+
+	values := f.values[:0:0]
+	for _, v := range f.values {
+		if !replaced[v.Key] {
+			values = append(values, v)
+		}
+	}
+	f.values = append(values, other.values...)
+}
+
 // Set sets the first instance of a parameter for the given key to the given
 // value. If no parameters exist with the key, a new one is added.
 //
@@ -468,3 +468,18 @@ func TestValues(t *testing.T) {
 
 	assert.Nil(t, values.Get("boguskey"))
 }
+
+func TestValuesMerge(t *testing.T) {
+	values := &Values{}
+	values.Add("symbols", "AAPL")
+	values.Add("lang", "en-US")
+
+	extra := &Values{}
+	extra.Add("lang", "en-GB")
+	extra.Add("region", "GB")
+
+	values.Merge(extra)
+	values.Merge(nil)
+
+	assert.Equal(t, "symbols=AAPL&lang=en-GB&region=GB", values.Encode())
+}
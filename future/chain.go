@@ -0,0 +1,51 @@
+package future
+
+import (
+	"sort"
+	"strings"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/quotesummary"
+)
+
+// GetChain returns the listed contract months of a futures
+// root, such as CL or CL=F, sorted by expiration.
+func GetChain(root string) ([]finance.Future, error) {
+	return getC().GetChain(root)
+}
+
+// GetChain returns the listed contract months of a futures
+// root, such as CL or CL=F, sorted by expiration.
+func (c Client) GetChain(root string) ([]finance.Future, error) {
+	if len(root) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+	if !strings.HasSuffix(root, "=F") {
+		root += "=F"
+	}
+
+	summary, err := quotesummary.Client{B: c.B}.GetP(&quotesummary.Params{
+		Symbol:  root,
+		Modules: []string{quotesummary.ModuleFuturesChain},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if summary.FuturesChain == nil || len(summary.FuturesChain.Futures) == 0 {
+		return nil, finance.CreateRemoteErrorS("no futures chain in quote summary response")
+	}
+
+	iter := c.ListP(&Params{Symbols: summary.FuturesChain.Futures})
+	chain := []finance.Future{}
+	for iter.Next() {
+		chain = append(chain, *iter.Future())
+	}
+	if iter.Err() != nil {
+		return nil, iter.Err()
+	}
+
+	sort.SliceStable(chain, func(i, j int) bool {
+		return chain[i].ExpireDate < chain[j].ExpireDate
+	})
+	return chain, nil
+}
@@ -1,9 +1,13 @@
 package future
 
 import (
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
 	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/financetest"
 	tests "github.com/fijoyapp/finance-go/testing"
 	"github.com/stretchr/testify/assert"
 )
@@ -18,3 +22,57 @@ func TestGetFuture(t *testing.T) {
 	assert.Equal(t, finance.MarketStateRegular, q.MarketState)
 	assert.Equal(t, tests.TestFutureSymbol, q.Symbol)
 }
+
+func TestGetFutureContract(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"quoteResponse":{"result":[
+			{"symbol":"CLZ24.NYM","quoteType":"FUTURE","underlyingSymbol":"CL=F","headSymbolAsString":"CL=F","contractSymbol":true,"expireDate":1734480000},
+			{"symbol":"CL=F","quoteType":"FUTURE","underlyingSymbol":"CLZ24.NYM","contractSymbol":false}
+		]}}`)
+	}))
+	c := Client{B: backend}
+
+	iter := c.ListP(&Params{Symbols: []string{"CLZ24.NYM", "CL=F"}})
+	assert.True(t, iter.Next())
+	f := iter.Future()
+	assert.True(t, f.IsContractSymbol)
+	assert.Equal(t, "CL=F", f.UnderlyingSymbol)
+	assert.Equal(t, "CL=F", f.HeadSymbolAsString)
+	assert.Equal(t, 2024, f.Expiration().Year)
+	assert.Equal(t, 12, f.Expiration().Month)
+	assert.Equal(t, 18, f.Expiration().Day)
+
+	assert.True(t, iter.Next())
+	assert.Nil(t, iter.Future().Expiration())
+}
+
+func TestGetChain(t *testing.T) {
+	var summaryPath, symbols string
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "quoteSummary") {
+			summaryPath = r.URL.Path
+			fmt.Fprint(w, `{"quoteSummary":{"result":[{"futuresChain":{"futures":["CLF25.NYM","CLZ24.NYM"]}}]}}`)
+			return
+		}
+		symbols = r.URL.Query().Get("symbols")
+		fmt.Fprint(w, `{"quoteResponse":{"result":[
+			{"symbol":"CLF25.NYM","expireDate":1737072000},
+			{"symbol":"CLZ24.NYM","expireDate":1734480000}
+		]}}`)
+	}))
+	c := Client{B: backend}
+
+	chain, err := c.GetChain("CL")
+	assert.Nil(t, err)
+	assert.True(t, strings.HasSuffix(summaryPath, "/CL=F"))
+	assert.Equal(t, "CLF25.NYM,CLZ24.NYM", symbols)
+	assert.Len(t, chain, 2)
+	assert.Equal(t, "CLZ24.NYM", chain[0].Symbol)
+	assert.Equal(t, "CLF25.NYM", chain[1].Symbol)
+}
+
+func TestGetChainNoRoot(t *testing.T) {
+	chain, err := GetChain("")
+	assert.Nil(t, chain)
+	assert.EqualError(t, err, "code: api-error, detail: missing function argument")
+}
@@ -0,0 +1,217 @@
+package history
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/chart"
+	"github.com/fijoyapp/finance-go/datetime"
+	"github.com/fijoyapp/finance-go/dividend"
+	"github.com/fijoyapp/finance-go/split"
+)
+
+// Events selects the rows of a download, like the
+// events parameter of the old v7 download endpoint.
+type Events string
+
+const (
+	// EventsHistory downloads daily bars. This is the default.
+	EventsHistory Events = "history"
+	// EventsDividends downloads dividends.
+	EventsDividends Events = "div"
+	// EventsSplits downloads stock splits.
+	EventsSplits Events = "split"
+)
+
+// Headers of the classic csv downloads.
+var (
+	historyHeader   = []string{"Date", "Open", "High", "Low", "Close", "Adj Close", "Volume"}
+	dividendsHeader = []string{"Date", "Dividends"}
+	splitsHeader    = []string{"Date", "Stock Splits"}
+	eventsHeader    = []string{"Date", "Open", "High", "Low", "Close", "Adj Close", "Volume", "Dividends", "Stock Splits"}
+)
+
+// Client is used to invoke history download APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and download information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	// Start and End bound the download.
+	// The full history is downloaded if Start is nil.
+	Start *datetime.Datetime `form:"-"`
+	End   *datetime.Datetime `form:"-"`
+	// Events selects bars, dividends or splits.
+	// Defaults to EventsHistory.
+	Events Events `form:"-"`
+	// IncludeEvents adds Dividends and Stock Splits columns to a
+	// history download, on the row of the date of each event.
+	IncludeEvents bool `form:"-"`
+}
+
+// Download returns the daily history of a symbol as csv, in the format
+// of the old v7 download endpoint, with a Date,Open,High,Low,Close,
+// Adj Close,Volume header. Params may be nil to download every bar.
+// The download is fetched and encoded in full before Download returns,
+// so the reader is backed by memory rather than streamed.
+func Download(symbol string, params *Params) (io.ReadCloser, error) {
+	return getC().Download(symbol, params)
+}
+
+// Download returns the daily history of a symbol as csv.
+func (c Client) Download(symbol string, params *Params) (io.ReadCloser, error) {
+	if len(symbol) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+	if params == nil {
+		params = &Params{}
+	}
+
+	var records [][]string
+	var err error
+	switch params.Events {
+	case "", EventsHistory:
+		records, err = c.history(symbol, params)
+	case EventsDividends:
+		records, err = c.dividends(symbol, params)
+		records = append([][]string{dividendsHeader}, records...)
+	case EventsSplits:
+		records, err = c.splits(symbol, params)
+		records = append([][]string{splitsHeader}, records...)
+	default:
+		return nil, finance.CreateArgumentErrorS(fmt.Sprintf("unsupported events %q", params.Events))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := csv.NewWriter(buf).WriteAll(records); err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(buf), nil
+}
+
+// history returns the header and daily bar records of a symbol,
+// with its dividends and splits if params asks for them.
+func (c Client) history(symbol string, params *Params) ([][]string, error) {
+	p := &chart.Params{
+		Params:   params.Params,
+		Symbol:   symbol,
+		Start:    params.Start,
+		End:      params.End,
+		Interval: datetime.OneDay,
+	}
+	if params.Start == nil {
+		p.Range = string(datetime.Max)
+	} else if params.End == nil {
+		p.End = datetime.Now()
+	}
+	if params.IncludeEvents {
+		p.Events = "div|split"
+	}
+
+	iter := chart.Client{B: c.B}.Get(p)
+	records := [][]string{historyHeader}
+	for iter.Next() {
+		records = append(records, chart.BarRecord(iter.Bar(), "2006-01-02"))
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	if !params.IncludeEvents {
+		return records, nil
+	}
+	return mergeEvents(records, iter.Events()), nil
+}
+
+// mergeEvents widens bar records to the columns of eventsHeader and
+// fills in the dividends and splits on the row of their date. Events
+// on a date without a bar get a row of their own, in date order.
+func mergeEvents(records [][]string, events *finance.ChartEvents) [][]string {
+	rows := map[string][]string{}
+	for i := 1; i < len(records); i++ {
+		records[i] = append(records[i], "", "")
+		rows[records[i][0]] = records[i]
+	}
+
+	row := func(date string) []string {
+		if r, ok := rows[date]; ok {
+			return r
+		}
+		r := make([]string, len(eventsHeader))
+		r[0] = date
+		rows[date] = r
+		records = append(records, r)
+		return r
+	}
+	if events != nil {
+		for _, d := range events.Dividends {
+			row(formatDate(d.Date))[7] = strconv.FormatFloat(d.Amount, 'f', -1, 64)
+		}
+		for _, s := range events.Splits {
+			row(formatDate(s.Date))[8] = s.Ratio
+		}
+	}
+
+	records[0] = eventsHeader
+	body := records[1:]
+	sort.SliceStable(body, func(i, j int) bool {
+		return body[i][0] < body[j][0]
+	})
+	return records
+}
+
+// dividends returns the date and amount records of the dividends of a symbol.
+func (c Client) dividends(symbol string, params *Params) ([][]string, error) {
+	iter := dividend.Client{B: c.B}.Get(&dividend.Params{
+		Params: params.Params,
+		Symbol: symbol,
+		Start:  params.Start,
+		End:    params.End,
+	})
+
+	records := [][]string{}
+	for iter.Next() {
+		d := iter.Dividend()
+		records = append(records, []string{formatDate(d.Date), strconv.FormatFloat(d.Amount, 'f', -1, 64)})
+	}
+	return records, iter.Err()
+}
+
+// splits returns the date and ratio records of the stock splits of a symbol.
+func (c Client) splits(symbol string, params *Params) ([][]string, error) {
+	iter := split.Client{B: c.B}.Get(&split.Params{
+		Params: params.Params,
+		Symbol: symbol,
+		Start:  params.Start,
+		End:    params.End,
+	})
+
+	records := [][]string{}
+	for iter.Next() {
+		s := iter.Split()
+		records = append(records, []string{formatDate(s.Date), s.Ratio})
+	}
+	return records, iter.Err()
+}
+
+// formatDate formats an event date as YYYY-MM-DD.
+func formatDate(d datetime.Datetime) string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
@@ -0,0 +1,99 @@
+package history
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownload(t *testing.T) {
+	c := newTestClient(t, `{"meta":{"symbol":"AAPL"},"timestamp":[],"indicators":{"quote":[{}]}}`)
+	r, err := c.Download("AAPL", nil)
+	assert.Nil(t, err)
+	defer r.Close()
+
+	b, err := ioutil.ReadAll(r)
+	assert.Nil(t, err)
+	assert.Equal(t, "Date,Open,High,Low,Close,Adj Close,Volume\n", string(b))
+}
+
+func TestDownloadNoSymbol(t *testing.T) {
+	r, err := Download("", nil)
+	assert.Nil(t, r)
+	assert.EqualError(t, err, "code: api-error, detail: missing function argument")
+}
+
+// newTestClient returns a client for a server
+// responding with the given chart result.
+func newTestClient(t *testing.T, result string) Client {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"chart":{"result":[%s]}}`, result)
+	}))
+	return Client{B: backend}
+}
+
+func TestDownloadCSV(t *testing.T) {
+	c := newTestClient(t, `{
+		"meta":{"symbol":"AAPL","exchangeTimezoneName":"America/New_York"},
+		"timestamp":[1704205800,1704292200],
+		"events":{
+			"dividends":{"1707489000":{"amount":0.24,"date":1707489000}},
+			"splits":{"1598880600":{"numerator":4,"denominator":1,"splitRatio":"4:1","date":1598880600}}
+		},
+		"indicators":{
+			"quote":[{"open":[187.15,184.22],"high":[188.44,185.88],"low":[183.89,183.43],"close":[185.64,184.25],"volume":[82488700,58414500]}],
+			"adjclose":[{"adjclose":[184.94,183.55]}]
+		}
+	}`)
+
+	read := func(params *Params) string {
+		r, err := c.Download("AAPL", params)
+		assert.Nil(t, err)
+		b, err := ioutil.ReadAll(r)
+		assert.Nil(t, err)
+		assert.Nil(t, r.Close())
+		return string(b)
+	}
+
+	assert.Equal(t, "Date,Open,High,Low,Close,Adj Close,Volume\n"+
+		"2024-01-02,187.15,188.44,183.89,185.64,184.94,82488700\n"+
+		"2024-01-03,184.22,185.88,183.43,184.25,183.55,58414500\n", read(nil))
+	assert.Equal(t, "Date,Dividends\n2024-02-09,0.24\n", read(&Params{Events: EventsDividends}))
+	assert.Equal(t, "Date,Stock Splits\n2020-08-31,4:1\n", read(&Params{Events: EventsSplits}))
+
+	_, err := c.Download("AAPL", &Params{Events: "bogus"})
+	assert.EqualError(t, err, `code: api-error, detail: unsupported events "bogus"`)
+}
+
+func TestDownloadIncludeEvents(t *testing.T) {
+	var events []string
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		events = append(events, r.URL.Query().Get("events"))
+		fmt.Fprint(w, `{"chart":{"result":[{
+			"meta":{"symbol":"AAPL","exchangeTimezoneName":"America/New_York"},
+			"timestamp":[1704205800,1704292200],
+			"events":{
+				"dividends":{"1704292200":{"amount":0.24,"date":1704292200}},
+				"splits":{"1598880600":{"numerator":4,"denominator":1,"splitRatio":"4:1","date":1598880600}}
+			},
+			"indicators":{
+				"quote":[{"open":[187.15,184.22],"high":[188.44,185.88],"low":[183.89,183.43],"close":[185.64,184.25],"volume":[82488700,58414500]}],
+				"adjclose":[{"adjclose":[184.94,183.55]}]
+			}
+		}]}}`)
+	}))
+
+	r, err := Client{B: backend}.Download("AAPL", &Params{IncludeEvents: true})
+	assert.Nil(t, err)
+	b, err := ioutil.ReadAll(r)
+	assert.Nil(t, err)
+	assert.Equal(t, "Date,Open,High,Low,Close,Adj Close,Volume,Dividends,Stock Splits\n"+
+		"2020-08-31,,,,,,,,4:1\n"+
+		"2024-01-02,187.15,188.44,183.89,185.64,184.94,82488700,,\n"+
+		"2024-01-03,184.22,185.88,183.43,184.25,183.55,58414500,0.24,\n", string(b))
+	assert.Equal(t, []string{"div|split"}, events)
+}
@@ -0,0 +1,90 @@
+package holders
+
+import (
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/quotesummary"
+)
+
+// Client is used to invoke holders APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and symbol information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Symbol string `form:"-"`
+}
+
+// GetInstitutional returns the institutional holders of a symbol.
+// Symbols without institutional coverage return an empty list.
+func GetInstitutional(symbol string) ([]finance.Holder, error) {
+	return GetInstitutionalP(&Params{Symbol: symbol})
+}
+
+// GetInstitutionalP returns institutional holders and requires
+// a params struct as an argument.
+func GetInstitutionalP(params *Params) ([]finance.Holder, error) {
+	return getC().GetInstitutionalP(params)
+}
+
+// GetInstitutionalP returns institutional holders.
+func (c Client) GetInstitutionalP(params *Params) ([]finance.Holder, error) {
+	summary, err := c.summary(params, quotesummary.ModuleInstitutionOwnership)
+	if err != nil {
+		return nil, err
+	}
+
+	if summary.InstitutionOwnership == nil || summary.InstitutionOwnership.OwnershipList == nil {
+		return []finance.Holder{}, nil
+	}
+
+	return summary.InstitutionOwnership.OwnershipList, nil
+}
+
+// GetMajor returns the ownership breakdown of a symbol.
+func GetMajor(symbol string) (*finance.MajorHoldersBreakdown, error) {
+	return GetMajorP(&Params{Symbol: symbol})
+}
+
+// GetMajorP returns an ownership breakdown and requires
+// a params struct as an argument.
+func GetMajorP(params *Params) (*finance.MajorHoldersBreakdown, error) {
+	return getC().GetMajorP(params)
+}
+
+// GetMajorP returns an ownership breakdown.
+func (c Client) GetMajorP(params *Params) (*finance.MajorHoldersBreakdown, error) {
+	summary, err := c.summary(params, quotesummary.ModuleMajorHoldersBreakdown)
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown := summary.MajorHoldersBreakdown
+	if breakdown == nil {
+		breakdown = &finance.MajorHoldersBreakdown{}
+	}
+	breakdown.Symbol = params.Symbol
+
+	return breakdown, nil
+}
+
+// summary fetches the given quote summary modules for the params symbol.
+func (c Client) summary(params *Params, modules ...string) (*finance.QuoteSummary, error) {
+	if params == nil || len(params.Symbol) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	return quotesummary.Client{B: c.B}.GetP(&quotesummary.Params{
+		Params:  params.Params,
+		Symbol:  params.Symbol,
+		Modules: modules,
+	})
+}
@@ -0,0 +1,67 @@
+package holders
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient returns a client for a server
+// responding with the given quote summary result.
+func newTestClient(t *testing.T, result string) Client {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"quoteSummary":{"result":[%s]}}`, result)
+	}))
+	return Client{B: backend}
+}
+
+func TestGetInstitutional(t *testing.T) {
+	c := newTestClient(t, `{"institutionOwnership":{"ownershipList":[
+		{"organization":"Vanguard Group Inc","reportDate":1711843200,"pctHeld":0.0842,"position":1300000000,"value":222000000000,"pctChange":0.012},
+		{"organization":"Blackrock Inc.","reportDate":1711843200,"pctHeld":0.0658,"position":1010000000,"value":173000000000,"pctChange":-0.004}
+	]}}`)
+
+	holders, err := c.GetInstitutionalP(&Params{Symbol: "AAPL"})
+
+	assert.Nil(t, err)
+	assert.Len(t, holders, 2)
+	assert.Equal(t, "Vanguard Group Inc", holders[0].Organization)
+	assert.Equal(t, 0.0842, holders[0].PercentHeld)
+	assert.Equal(t, int64(1300000000), holders[0].SharesHeld)
+	assert.Equal(t, 1711843200, holders[0].ReportDate.Unix())
+}
+
+func TestGetInstitutionalNoCoverage(t *testing.T) {
+	c := newTestClient(t, `{}`)
+
+	holders, err := c.GetInstitutionalP(&Params{Symbol: "EURUSD=X"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, holders)
+	assert.Empty(t, holders)
+}
+
+func TestGetMajor(t *testing.T) {
+	c := newTestClient(t, `{"majorHoldersBreakdown":{
+		"insidersPercentHeld":0.0007,"institutionsPercentHeld":0.6123,
+		"institutionsFloatPercentHeld":0.6127,"institutionsCount":6120
+	}}`)
+
+	breakdown, err := c.GetMajorP(&Params{Symbol: "AAPL"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, breakdown)
+	assert.Equal(t, "AAPL", breakdown.Symbol)
+	assert.Equal(t, 6120, breakdown.InstitutionsCount)
+	assert.Equal(t, 0.6123, breakdown.InstitutionsPercentHeld)
+}
+
+func TestNilParamsHolders(t *testing.T) {
+	holders, err := GetInstitutionalP(nil)
+
+	assert.Nil(t, holders)
+	assert.Equal(t, "code: api-error, detail: missing function argument", err.Error())
+}
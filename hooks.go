@@ -0,0 +1,62 @@
+package finance
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// requestHook and responseHook are the
+	// hooks set with SetRequestHook and SetResponseHook.
+	requestHook  atomic.Pointer[func(*http.Request)]
+	responseHook atomic.Pointer[func(*http.Response, time.Duration)]
+)
+
+// SetRequestHook sets a function called with every request sent to
+// the api, including retries, just before it is sent. Requests that
+// fail before they can be sent, because the circuit breaker is open,
+// the rate limit wait is canceled or no crumb can be fetched, are
+// passed to the hook too. This is useful for metrics and tracing.
+// Passing nil removes the hook.
+func SetRequestHook(hook func(*http.Request)) {
+	if hook == nil {
+		requestHook.Store(nil)
+		return
+	}
+	requestHook.Store(&hook)
+}
+
+// SetResponseHook sets a function called with the response to every
+// request sent to the api and how long it took, including error
+// responses. The response is nil if the request failed before one
+// was received, including requests that were never sent. The body has already been read when the hook is
+// called. Passing nil removes the hook.
+func SetResponseHook(hook func(*http.Response, time.Duration)) {
+	if hook == nil {
+		responseHook.Store(nil)
+		return
+	}
+	responseHook.Store(&hook)
+}
+
+// onRequest calls the request hook, if any.
+func onRequest(req *http.Request) {
+	if hook := requestHook.Load(); hook != nil {
+		(*hook)(req)
+	}
+}
+
+// onResponse calls the response hook, if any.
+func onResponse(res *http.Response, start time.Time) {
+	if hook := responseHook.Load(); hook != nil {
+		(*hook)(res, time.Since(start))
+	}
+}
+
+// onUnsent calls the hooks for a request that failed before it could
+// be sent, with a nil response.
+func onUnsent(req *http.Request, start time.Time) {
+	onRequest(req)
+	onResponse(nil, start)
+}
@@ -1,9 +1,12 @@
 package index
 
 import (
+	"fmt"
+	"net/http"
 	"testing"
 
 	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/financetest"
 	tests "github.com/fijoyapp/finance-go/testing"
 	"github.com/stretchr/testify/assert"
 )
@@ -56,3 +59,34 @@ func TestGetBadIndex(t *testing.T) {
 	assert.Nil(t, q)
 	assert.Nil(t, err)
 }
+
+// newTestClient returns a client for a server
+// that always responds with the given status and body.
+func newTestClient(t *testing.T, status int, body string) Client {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	return Client{B: backend}
+}
+
+func TestGetComponents(t *testing.T) {
+	c := newTestClient(t, http.StatusOK, `{"quoteSummary":{"result":[{"components":{"components":["AAPL","MSFT"],"maxAge":1}}]}}`)
+
+	components, err := c.GetComponents("^DJI")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"AAPL", "MSFT"}, components)
+}
+
+func TestGetComponentsUnavailable(t *testing.T) {
+	c := newTestClient(t, http.StatusOK, `{"quoteSummary":{"result":[{}]}}`)
+	components, err := c.GetComponents("^VIX")
+	assert.Nil(t, components)
+	assert.Equal(t, ErrNoComponents, err)
+
+	c = newTestClient(t, http.StatusNotFound, `{"quoteSummary":{"result":null,"error":{"code":"Not Found","description":"No fundamentals data found for symbol: ^VIX"}}}`)
+	components, err = c.GetComponents("^VIX")
+	assert.Nil(t, components)
+	assert.ErrorIs(t, err, ErrNoComponents)
+	assert.ErrorIs(t, err, finance.ErrNotFound)
+}
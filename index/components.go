@@ -0,0 +1,42 @@
+package index
+
+import (
+	"errors"
+	"fmt"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/quotesummary"
+)
+
+// ErrNoComponents is matched by errors for indices whose constituents
+// yahoo doesn't list. For indices yahoo doesn't know at all, the
+// error matches finance.ErrNotFound too.
+var ErrNoComponents = errors.New("no components for index")
+
+// GetComponents returns the constituent symbols of an index, such as ^DJI.
+func GetComponents(symbol string) ([]string, error) {
+	return getC().GetComponents(symbol)
+}
+
+// GetComponents returns the constituent symbols of an index, such as ^DJI.
+func (c Client) GetComponents(symbol string) ([]string, error) {
+	if len(symbol) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	summary, err := quotesummary.Client{B: c.B}.GetP(&quotesummary.Params{
+		Symbol:  symbol,
+		Modules: []string{quotesummary.ModuleComponents},
+	})
+	if errors.Is(err, finance.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %w", ErrNoComponents, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if summary.Components == nil || len(summary.Components.Components) == 0 {
+		return nil, ErrNoComponents
+	}
+	return summary.Components.Components, nil
+}
@@ -0,0 +1,83 @@
+package insider
+
+import (
+	"strings"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/quotesummary"
+)
+
+// Client is used to invoke insider transaction APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and symbol information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Symbol string `form:"-"`
+}
+
+// GetTransactions returns the reported insider transactions for a symbol.
+func GetTransactions(symbol string) ([]finance.InsiderTransaction, error) {
+	return GetTransactionsP(&Params{Symbol: symbol})
+}
+
+// GetTransactionsP returns insider transactions and requires
+// a params struct as an argument.
+func GetTransactionsP(params *Params) ([]finance.InsiderTransaction, error) {
+	return getC().GetTransactionsP(params)
+}
+
+// GetTransactionsP returns insider transactions.
+func (c Client) GetTransactionsP(params *Params) ([]finance.InsiderTransaction, error) {
+	if params == nil || len(params.Symbol) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	summary, err := quotesummary.Client{B: c.B}.GetP(&quotesummary.Params{
+		Params:  params.Params,
+		Symbol:  params.Symbol,
+		Modules: []string{quotesummary.ModuleInsiderTransactions},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if summary.InsiderTransactions == nil {
+		return nil, nil
+	}
+
+	transactions := summary.InsiderTransactions.Transactions
+	for i := range transactions {
+		transactions[i].IsAcquisition = isAcquisition(transactions[i].TransactionText)
+	}
+
+	return transactions, nil
+}
+
+// acquisitionTerms are the transaction text terms
+// yahoo uses for insider acquisitions.
+var acquisitionTerms = []string{"purchase", "buy", "award", "grant", "exercise", "acquisition"}
+
+// isAcquisition reports whether a transaction text describes
+// an acquisition rather than a sale or other disposal.
+func isAcquisition(text string) bool {
+	text = strings.ToLower(text)
+	if strings.Contains(text, "sale") {
+		return false
+	}
+	for _, term := range acquisitionTerms {
+		if strings.Contains(text, term) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package insider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTransactions(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"quoteSummary":{"result":[{"insiderTransactions":{"transactions":[
+			{"filerName":"COOK TIMOTHY D","filerRelation":"Chief Executive Officer",
+			 "transactionText":"Sale at price 178.85 per share.","shares":196410,"value":35128000,"startDate":1696291200},
+			{"filerName":"LEVINSON ARTHUR D","filerRelation":"Director",
+			 "transactionText":"Stock Award(Grant) at price 0.00 per share.","shares":1276,"startDate":1696204800}
+		]}}]}}`))
+	}))
+	c := Client{B: backend}
+
+	transactions, err := c.GetTransactionsP(&Params{Symbol: "AAPL"})
+
+	assert.Nil(t, err)
+	assert.Len(t, transactions, 2)
+	assert.Equal(t, "COOK TIMOTHY D", transactions[0].FilerName)
+	assert.Equal(t, int64(196410), transactions[0].Shares)
+	assert.Equal(t, 1696291200, transactions[0].StartDate.Unix())
+	assert.False(t, transactions[0].IsAcquisition)
+	assert.True(t, transactions[1].IsAcquisition)
+}
+
+func TestNilParamsTransactions(t *testing.T) {
+	transactions, err := GetTransactionsP(nil)
+
+	assert.Nil(t, transactions)
+	assert.Equal(t, "code: api-error, detail: missing function argument", err.Error())
+}
+
+func TestIsAcquisition(t *testing.T) {
+	assert.True(t, isAcquisition("Purchase at price 150.00 per share."))
+	assert.True(t, isAcquisition("Stock Award(Grant) at price 0.00 per share."))
+	assert.True(t, isAcquisition("Conversion of Exercise of derivative security"))
+	assert.False(t, isAcquisition("Sale at price 150.00 per share."))
+	assert.False(t, isAcquisition("Stock Gift at price 0.00 per share."))
+	assert.False(t, isAcquisition(""))
+}
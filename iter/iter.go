@@ -1,12 +1,19 @@
 package iter
 
 import (
+	"fmt"
+
 	"github.com/fijoyapp/finance-go/form"
 )
 
 // Query is the function used to get a response listing.
 type Query = func(*form.Values) (interface{}, []interface{}, error)
 
+// PageQuery is the function used to get a page of a response
+// listing starting at offset, along with the total number of
+// items in the listing as reported by the api.
+type PageQuery = func(offset int) (meta interface{}, values []interface{}, total int, err error)
+
 // Iter provides a convenient interface
 // for iterating over the elements
 // returned from paginated list API calls.
@@ -19,6 +26,9 @@ type Iter struct {
 	cur    interface{}
 	err    error
 	values []interface{}
+	all    []interface{}
+	total  int
+	page   PageQuery
 }
 
 // NewE returns a iter wrapping an error.
@@ -38,9 +48,41 @@ func New(qs *form.Values, query Query) *Iter {
 	}
 
 	iter.meta, iter.values, iter.err = query(q)
+	iter.all = iter.values
+	iter.total = len(iter.values)
+	return iter
+}
+
+// NewPaged returns a new instance of Iter for a paginated query.
+// Pages are fetched as the iterator advances, until the total
+// reported by the api is reached or a page comes back empty.
+func NewPaged(query PageQuery) *Iter {
+	iter := &Iter{page: query}
+	iter.meta, iter.values, iter.total, iter.err = query(0)
+	iter.all = iter.values
+	iter.settle()
 	return iter
 }
 
+// fetch requests the page following the items fetched so far.
+func (it *Iter) fetch() {
+	var values []interface{}
+	_, values, it.total, it.err = it.page(len(it.all))
+	it.all = append(it.all, values...)
+	it.values = values
+	it.settle()
+}
+
+// settle stops paging once every item has been fetched, or if
+// the last page failed or came back empty, so that the total
+// matches the items actually available.
+func (it *Iter) settle() {
+	if it.err != nil || len(it.values) == 0 || len(it.all) >= it.total {
+		it.page = nil
+		it.total = len(it.all)
+	}
+}
+
 // Next advances the Iter to the next item in the list,
 // which will then be available
 // through the Current method.
@@ -48,6 +90,10 @@ func New(qs *form.Values, query Query) *Iter {
 // at the end of the list.
 func (it *Iter) Next() bool {
 
+	if len(it.values) == 0 && it.page != nil {
+		it.fetch()
+	}
+
 	if it.values == nil {
 		return false
 	}
@@ -61,6 +107,16 @@ func (it *Iter) Next() bool {
 	return true
 }
 
+// Reset rewinds the iterator to the start of the list,
+// so that it can be visited again without refetching it.
+// Fetched items are kept, so iterators can always be reset;
+// a paged iterator goes on to fetch the pages it hasn't yet.
+func (it *Iter) Reset() error {
+	it.values = it.all
+	it.cur = nil
+	return nil
+}
+
 // Current returns the most recent item
 // visited by a call to Next.
 func (it *Iter) Current() interface{} {
@@ -81,7 +137,31 @@ func (it *Iter) Err() error {
 	return it.err
 }
 
-// Count returns the list count.
+// Count returns the total number of items in the list,
+// including those already visited, so that progress can be
+// reported as "N of Count". Paged iterators report the total
+// given by the api, including pages not yet fetched.
 func (it *Iter) Count() int {
-	return len(it.values)
+	return it.total
+}
+
+// Remaining returns the number of items
+// not yet visited by a call to Next.
+func (it *Iter) Remaining() int {
+	return it.total - (len(it.all) - len(it.values))
+}
+
+// Collect drains the remaining items of an iterator into a slice,
+// returning the iterator's error, if any. Concrete iterators embed
+// an *Iter, for example iter.Collect[*finance.Quote](quotes.Iter).
+func Collect[T any](it *Iter) ([]T, error) {
+	items := make([]T, 0, it.Remaining())
+	for it.Next() {
+		item, ok := it.Current().(T)
+		if !ok {
+			return items, fmt.Errorf("iter: cannot collect %T as %T", it.Current(), item)
+		}
+		items = append(items, item)
+	}
+	return items, it.Err()
 }
@@ -0,0 +1,126 @@
+package iter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fijoyapp/finance-go/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestIter(values ...interface{}) *Iter {
+	return New(nil, func(*form.Values) (interface{}, []interface{}, error) {
+		return nil, values, nil
+	})
+}
+
+func TestIterCount(t *testing.T) {
+	it := newTestIter(1, 2, 3)
+	assert.Equal(t, 3, it.Count())
+	assert.Equal(t, 3, it.Remaining())
+
+	assert.True(t, it.Next())
+	assert.Equal(t, 3, it.Count())
+	assert.Equal(t, 2, it.Remaining())
+}
+
+func TestIterCountError(t *testing.T) {
+	it := NewE(errors.New("failed"))
+	assert.Equal(t, 0, it.Count())
+	assert.Equal(t, 0, it.Remaining())
+}
+
+func TestCollect(t *testing.T) {
+	items, err := Collect[int](newTestIter(1, 2, 3))
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3}, items)
+
+	items, err = Collect[int](NewE(errors.New("failed")))
+	assert.Empty(t, items)
+	assert.EqualError(t, err, "failed")
+
+	_, err = Collect[string](newTestIter(1))
+	assert.EqualError(t, err, "iter: cannot collect int as string")
+}
+
+func TestIterReset(t *testing.T) {
+	it := newTestIter(1, 2)
+	for it.Next() {
+	}
+	assert.Nil(t, it.Reset())
+	assert.Nil(t, it.Current())
+	assert.Equal(t, 2, it.Remaining())
+
+	items, err := Collect[int](it)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2}, items)
+}
+
+// newTestPagedIter returns a paged iter over items,
+// served in pages of the given size.
+func newTestPagedIter(items []interface{}, size int, offsets *[]int) *Iter {
+	return NewPaged(func(offset int) (interface{}, []interface{}, int, error) {
+		*offsets = append(*offsets, offset)
+		end := offset + size
+		if end > len(items) {
+			end = len(items)
+		}
+		return nil, items[offset:end], len(items), nil
+	})
+}
+
+func TestPagedIter(t *testing.T) {
+	var offsets []int
+	it := newTestPagedIter([]interface{}{1, 2, 3, 4, 5}, 2, &offsets)
+	assert.Equal(t, 5, it.Count())
+	assert.Equal(t, 5, it.Remaining())
+
+	assert.True(t, it.Next())
+	assert.True(t, it.Next())
+	assert.Equal(t, 3, it.Remaining())
+
+	items, err := Collect[int](it)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{3, 4, 5}, items)
+	assert.Equal(t, []int{0, 2, 4}, offsets)
+
+	assert.Nil(t, it.Reset())
+	items, err = Collect[int](it)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, items)
+	assert.Equal(t, []int{0, 2, 4}, offsets)
+}
+
+func TestPagedIterShortTotal(t *testing.T) {
+	// The api reports more items than it serves.
+	pages := 0
+	it := NewPaged(func(offset int) (interface{}, []interface{}, int, error) {
+		pages++
+		if offset > 0 {
+			return nil, nil, 10, nil
+		}
+		return nil, []interface{}{1, 2}, 10, nil
+	})
+
+	items, err := Collect[int](it)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2}, items)
+	assert.Equal(t, 2, pages)
+	assert.Equal(t, 2, it.Count())
+	assert.False(t, it.Next())
+	assert.Equal(t, 2, pages)
+}
+
+func TestPagedIterError(t *testing.T) {
+	it := NewPaged(func(offset int) (interface{}, []interface{}, int, error) {
+		if offset > 0 {
+			return nil, nil, 0, errors.New("failed")
+		}
+		return nil, []interface{}{1}, 3, nil
+	})
+
+	items, err := Collect[int](it)
+	assert.Equal(t, []int{1}, items)
+	assert.EqualError(t, err, "failed")
+	assert.Equal(t, 0, it.Remaining())
+}
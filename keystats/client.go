@@ -0,0 +1,59 @@
+package keystats
+
+import (
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/quotesummary"
+)
+
+// Client is used to invoke key statistics APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and symbol information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Symbol string `form:"-"`
+}
+
+// Get returns the key statistics for a symbol.
+func Get(symbol string) (*finance.KeyStatistics, error) {
+	return GetP(&Params{Symbol: symbol})
+}
+
+// GetP returns key statistics and requires a params
+// struct as an argument.
+func GetP(params *Params) (*finance.KeyStatistics, error) {
+	return getC().GetP(params)
+}
+
+// GetP returns key statistics.
+func (c Client) GetP(params *Params) (*finance.KeyStatistics, error) {
+	if params == nil || len(params.Symbol) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	summary, err := quotesummary.Client{B: c.B}.GetP(&quotesummary.Params{
+		Params:  params.Params,
+		Symbol:  params.Symbol,
+		Modules: []string{quotesummary.ModuleDefaultKeyStatistics},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := summary.DefaultKeyStatistics
+	if stats == nil {
+		stats = &finance.KeyStatistics{}
+	}
+	stats.Symbol = params.Symbol
+
+	return stats, nil
+}
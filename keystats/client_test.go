@@ -0,0 +1,35 @@
+package keystats
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetKeyStatistics(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"quoteSummary":{"result":[{"defaultKeyStatistics":{
+			"sharesOutstanding":15204100096,"beta":1.264,"forwardPE":28.5,"52WeekChange":0.2153
+		}}]}}`))
+	}))
+	c := Client{B: backend}
+
+	stats, err := c.GetP(&Params{Symbol: "AAPL"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, stats)
+	assert.Equal(t, "AAPL", stats.Symbol)
+	assert.Equal(t, int64(15204100096), *stats.SharesOutstanding)
+	assert.Equal(t, 1.264, *stats.Beta)
+	assert.Equal(t, 0.2153, *stats.FiftyTwoWeekChange)
+	assert.Nil(t, stats.PegRatio)
+}
+
+func TestNilParamsKeyStatistics(t *testing.T) {
+	stats, err := GetP(nil)
+
+	assert.Nil(t, stats)
+	assert.Equal(t, "code: api-error, detail: missing function argument", err.Error())
+}
@@ -0,0 +1,67 @@
+package finance
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ListError collects the failures of requests made for many symbols,
+// or other keys such as dates, keyed by the symbol or key that failed.
+type ListError map[string]error
+
+// Error returns the failures ordered by key.
+func (e ListError) Error() string {
+	keys := make([]string, 0, len(e))
+	for k := range e {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	msgs := make([]string, len(keys))
+	for i, k := range keys {
+		msgs[i] = fmt.Sprintf("%s: %v", k, e[k])
+	}
+	return fmt.Sprintf("%d request(s) failed: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// FetchConcurrently calls fetch for each key, with at most workers
+// calls at once, and waits for all of them. It returns the values of
+// the calls that succeeded and the errors of those that failed, both
+// keyed by key.
+func FetchConcurrently[K comparable, V any](keys []K, workers int, fetch func(K) (V, error)) (map[K]V, map[K]error) {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[K]V, len(keys))
+		failed  = map[K]error{}
+		jobs    = make(chan K)
+	)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range jobs {
+				v, err := fetch(key)
+
+				mu.Lock()
+				if err != nil {
+					failed[key] = err
+				} else {
+					results[key] = v
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, key := range keys {
+		jobs <- key
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, failed
+}
@@ -0,0 +1,40 @@
+package finance
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListErrorMessage(t *testing.T) {
+	err := ListError{
+		"B": CreateRemoteErrorS("b"),
+		"A": CreateRemoteErrorS("a"),
+	}
+	assert.Equal(t, "2 request(s) failed: A: code: remote-error, detail: a; B: code: remote-error, detail: b", err.Error())
+}
+
+func TestFetchConcurrently(t *testing.T) {
+	var running, peak int32
+	results, failed := FetchConcurrently([]int{1, 2, 3, 4, 5}, 2, func(n int) (int, error) {
+		now := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if now <= p || atomic.CompareAndSwapInt32(&peak, p, now) {
+				break
+			}
+		}
+
+		if n == 3 {
+			return 0, errors.New("three")
+		}
+		return n * n, nil
+	})
+
+	assert.Equal(t, map[int]int{1: 1, 2: 4, 4: 16, 5: 25}, results)
+	assert.Equal(t, map[int]error{3: errors.New("three")}, failed)
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+}
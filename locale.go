@@ -0,0 +1,52 @@
+package finance
+
+import (
+	"sync"
+
+	"github.com/fijoyapp/finance-go/form"
+)
+
+// locale holds the region and language sent
+// with requests that yahoo localizes.
+var locale struct {
+	region, lang string
+	mu           sync.RWMutex
+}
+
+// SetRegion sets the region, such as GB, sent with quote, search and
+// trending requests. Yahoo's default, the US, applies if it's empty.
+func SetRegion(region string) {
+	locale.mu.Lock()
+	defer locale.mu.Unlock()
+	locale.region = region
+}
+
+// GetRegion returns the region set with SetRegion,
+// or an empty string if none is set.
+func GetRegion() string {
+	locale.mu.RLock()
+	defer locale.mu.RUnlock()
+	return locale.region
+}
+
+// SetLang sets the language, such as en-GB, sent with quote, search and
+// trending requests. Yahoo's default, en-US, applies if it's empty.
+func SetLang(lang string) {
+	locale.mu.Lock()
+	defer locale.mu.Unlock()
+	locale.lang = lang
+}
+
+// AppendLocale adds the region and language set
+// with SetRegion and SetLang to a request's values.
+// Values that aren't set are left out.
+func AppendLocale(values *form.Values) {
+	locale.mu.RLock()
+	defer locale.mu.RUnlock()
+	if locale.region != "" {
+		values.Set("region", locale.region)
+	}
+	if locale.lang != "" {
+		values.Set("lang", locale.lang)
+	}
+}
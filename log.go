@@ -0,0 +1,69 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// structured is the logger set with SetLogger, if any.
+var structured atomic.Pointer[slog.Logger]
+
+// SetLogger sets a structured logger for the library. Events are logged
+// with key/value fields such as method, url, status, duration_ms and
+// retry_count, and filtered by the logger's handler rather than LogLevel.
+// Passing nil goes back to logging lines through Logger.
+func SetLogger(l *slog.Logger) {
+	structured.Store(l)
+}
+
+// logEvent logs an event through the structured logger if one is set,
+// and otherwise as a line through Logger if LogLevel allows it.
+func logEvent(ctx context.Context, level slog.Level, msg string, args ...interface{}) {
+	if l := structured.Load(); l != nil {
+		l.Log(ctx, level, msg, args...)
+		return
+	}
+
+	// LogLevel 1 logs errors, 2 adds informational
+	// events and 3 adds debug events.
+	switch {
+	case LogLevel < 1,
+		LogLevel < 2 && level < slog.LevelError,
+		LogLevel < 3 && level < slog.LevelInfo:
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i+1 < len(args); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+	}
+	Logger.Printf("%s\n", b.String())
+}
+
+// LogEvent logs an event with key/value fields, the way the library
+// logs its own events: through the logger set with SetLogger if any,
+// and otherwise as a line through Logger if LogLevel allows it. It is
+// meant for the subpackages of this library.
+func LogEvent(ctx context.Context, level slog.Level, msg string, args ...interface{}) {
+	logEvent(ctx, level, msg, args...)
+}
+
+// requestFields returns the log fields describing a request.
+// The query, which carries the crumb, is left out of the url.
+func requestFields(req *http.Request, args ...interface{}) []interface{} {
+	return append([]interface{}{
+		"method", req.Method,
+		"url", req.URL.Host + req.URL.Path,
+	}, args...)
+}
+
+// durationMS returns the time since start in milliseconds.
+func durationMS(start time.Time) int64 {
+	return time.Since(start).Milliseconds()
+}
@@ -0,0 +1,117 @@
+package market
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/chart"
+	"github.com/fijoyapp/finance-go/datetime"
+)
+
+// ErrUnknownExchange is returned by Hours for
+// exchanges without a known reference symbol.
+var ErrUnknownExchange = errors.New("unknown exchange")
+
+// exchangeSymbols are reference symbols, usually the main
+// index, of exchanges keyed by their common and yahoo codes.
+var exchangeSymbols = map[string]string{
+	"NYSE":     "^NYA",
+	"NYQ":      "^NYA",
+	"NASDAQ":   "^IXIC",
+	"NMS":      "^IXIC",
+	"NGM":      "^IXIC",
+	"NCM":      "^IXIC",
+	"LSE":      "^FTSE",
+	"LON":      "^FTSE",
+	"TSX":      "^GSPTSE",
+	"TOR":      "^GSPTSE",
+	"JPX":      "^N225",
+	"TYO":      "^N225",
+	"OSA":      "^N225",
+	"HKEX":     "^HSI",
+	"HKG":      "^HSI",
+	"XETRA":    "^GDAXI",
+	"GER":      "^GDAXI",
+	"EURONEXT": "^FCHI",
+	"PAR":      "^FCHI",
+	"ASX":      "^AXJO",
+}
+
+// Client is used to invoke market hours APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Hours returns the current trading sessions of an exchange,
+// given by a common code like "NYSE" or a yahoo code like "NMS".
+func Hours(exchange string) (*finance.MarketHours, error) {
+	return getC().Hours(exchange)
+}
+
+// Hours returns the current trading sessions of an exchange.
+func (c Client) Hours(exchange string) (*finance.MarketHours, error) {
+	if len(exchange) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	symbol, ok := exchangeSymbols[strings.ToUpper(exchange)]
+	if !ok {
+		return nil, ErrUnknownExchange
+	}
+	return c.SymbolHours(symbol)
+}
+
+// SymbolHours returns the current trading
+// sessions of the exchange listing a symbol.
+func SymbolHours(symbol string) (*finance.MarketHours, error) {
+	return getC().SymbolHours(symbol)
+}
+
+// SymbolHours returns the current trading
+// sessions of the exchange listing a symbol.
+func (c Client) SymbolHours(symbol string) (*finance.MarketHours, error) {
+	if len(symbol) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	iter := chart.Client{B: c.B}.Get(&chart.Params{
+		Symbol:   symbol,
+		Range:    string(datetime.OneDay),
+		Interval: datetime.OneDay,
+	})
+	if iter.Err() != nil {
+		return nil, iter.Err()
+	}
+
+	meta := iter.Meta()
+	if meta == nil {
+		return nil, finance.CreateRemoteErrorS("no results in chart response")
+	}
+	return newHours(meta), nil
+}
+
+// newHours returns the trading sessions reported in chart metadata.
+func newHours(meta *finance.ChartMeta) *finance.MarketHours {
+	loc := meta.Location()
+	session := func(start, end int) finance.TradingSession {
+		return finance.TradingSession{
+			Start: time.Unix(int64(start), 0).In(loc),
+			End:   time.Unix(int64(end), 0).In(loc),
+		}
+	}
+
+	period := meta.CurrentTradingPeriod
+	return &finance.MarketHours{
+		Exchange: meta.ExchangeName,
+		Timezone: loc.String(),
+		Pre:      session(period.Pre.Start, period.Pre.End),
+		Regular:  session(period.Regular.Start, period.Regular.End),
+		Post:     session(period.Post.Start, period.Post.End),
+	}
+}
@@ -0,0 +1,63 @@
+package market
+
+import (
+	"net/http"
+	"testing"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSymbolHours(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v8/finance/chart/AAPL", r.URL.Path)
+		w.Write([]byte(`{"chart":{"result":[{
+			"meta":{"symbol":"AAPL","exchangeName":"NMS","exchangeTimezoneName":"America/New_York",
+				"currentTradingPeriod":{
+					"pre":{"start":1700816400,"end":1700836200},
+					"regular":{"start":1700836200,"end":1700859600},
+					"post":{"start":1700859600,"end":1700874000}}},
+			"timestamp":[1700836200],
+			"indicators":{"quote":[{"open":[190],"high":[190],"low":[189],"close":[189.9],"volume":[100]}]}
+		}],"error":null}}`))
+	}))
+	c := Client{B: backend}
+
+	hours, err := c.SymbolHours("AAPL")
+
+	assert.Nil(t, err)
+	assert.NotNil(t, hours)
+	assert.Equal(t, "NMS", hours.Exchange)
+	assert.Equal(t, "04:00", hours.Pre.Start.Format("15:04"))
+	assert.Equal(t, "09:30", hours.Regular.Start.Format("15:04"))
+	assert.Equal(t, "16:00", hours.Regular.End.Format("15:04"))
+	assert.Equal(t, "20:00", hours.Post.End.Format("15:04"))
+}
+
+func TestHoursUnknownExchange(t *testing.T) {
+	hours, err := Hours("XXXX")
+
+	assert.Nil(t, hours)
+	assert.Equal(t, ErrUnknownExchange, err)
+}
+
+func TestHoursNoExchange(t *testing.T) {
+	hours, err := Hours("")
+
+	assert.Nil(t, hours)
+	assert.Equal(t, "code: api-error, detail: missing function argument", err.Error())
+}
+
+func TestNewHours(t *testing.T) {
+	meta := &finance.ChartMeta{ExchangeName: "NMS", ExchangeTimezoneName: "America/New_York"}
+	// A half-day session, Nov 24 2023, closing at 13:00.
+	meta.CurrentTradingPeriod.Regular.Start = 1700836200
+	meta.CurrentTradingPeriod.Regular.End = 1700848800
+
+	hours := newHours(meta)
+	assert.Equal(t, "NMS", hours.Exchange)
+	assert.Equal(t, "America/New_York", hours.Timezone)
+	assert.Equal(t, "09:30", hours.Regular.Start.Format("15:04"))
+	assert.Equal(t, "13:00", hours.Regular.End.Format("15:04"))
+}
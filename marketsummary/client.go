@@ -0,0 +1,95 @@
+package marketsummary
+
+import (
+	"context"
+	"encoding/json"
+
+	finance "github.com/fijoyapp/finance-go"
+	form "github.com/fijoyapp/finance-go/form"
+)
+
+// DefaultRegion is the region used when none is specified.
+const DefaultRegion = "US"
+
+// Client is used to invoke market summary APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and region information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Region string `form:"region"`
+
+	// Internal request fields.
+	formatted string `form:"formatted"`
+}
+
+// Get returns quotes for the major indices, commodities and
+// currencies of a region, defaulting to the US if region is empty.
+func Get(region string) ([]finance.Quote, error) {
+	return GetP(&Params{Region: region})
+}
+
+// GetP returns a market summary and requires a params
+// struct as an argument.
+func GetP(params *Params) ([]finance.Quote, error) {
+	return getC().GetP(params)
+}
+
+// GetP returns a market summary.
+func (c Client) GetP(params *Params) ([]finance.Quote, error) {
+
+	if params == nil {
+		return nil, finance.CreateArgumentError()
+	}
+
+	if params.Context == nil {
+		ctx := context.TODO()
+		params.Context = &ctx
+	}
+
+	if params.Region == "" {
+		params.Region = DefaultRegion
+	}
+	// Request raw values rather than
+	// {"raw": 1.5, "fmt": "1.50"} objects.
+	params.formatted = "false"
+
+	body := &form.Values{}
+	form.AppendTo(body, params)
+
+	resp := response{}
+	err := c.B.Call("v6/finance/quote/marketSummary", body, params.Context, &resp)
+	if err != nil {
+		// Unknown regions are rejected with an
+		// error status, but still carry a yfin error.
+		if remoteErr, ok := err.(*finance.RemoteError); ok {
+			if json.Unmarshal([]byte(remoteErr.Body), &resp) == nil && resp.Inner.Error != nil {
+				return nil, finance.CreateRemoteError(resp.Inner.Error)
+			}
+		}
+		return nil, finance.CreateRemoteError(err)
+	}
+
+	if resp.Inner.Error != nil {
+		return nil, finance.CreateRemoteError(resp.Inner.Error)
+	}
+
+	return resp.Inner.Result, nil
+}
+
+// response is a yfin market summary response.
+type response struct {
+	Inner struct {
+		Result []finance.Quote    `json:"result"`
+		Error  *finance.YfinError `json:"error"`
+	} `json:"marketSummaryResponse"`
+}
@@ -0,0 +1,50 @@
+package marketsummary
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMarketSummary(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v6/finance/quote/marketSummary", r.URL.Path)
+		assert.Equal(t, DefaultRegion, r.URL.Query().Get("region"))
+		assert.Equal(t, "false", r.URL.Query().Get("formatted"))
+		w.Write([]byte(`{"marketSummaryResponse":{"result":[
+			{"symbol":"^GSPC","shortName":"S&P 500","regularMarketPrice":5021.84},
+			{"symbol":"^DJI","shortName":"Dow 30","regularMarketPrice":38627.99}
+		],"error":null}}`))
+	}))
+	c := Client{B: backend}
+
+	quotes, err := c.GetP(&Params{})
+
+	assert.Nil(t, err)
+	assert.Len(t, quotes, 2)
+	assert.Equal(t, "^GSPC", quotes[0].Symbol)
+	assert.Equal(t, 38627.99, quotes[1].RegularMarketPrice)
+}
+
+func TestGetMarketSummaryBadRegion(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"marketSummaryResponse":{"result":null,"error":{"code":"Bad Request","description":"invalid region XX"}}}`))
+	}))
+	c := Client{B: backend}
+
+	quotes, err := c.GetP(&Params{Region: "XX"})
+
+	assert.Nil(t, quotes)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid region XX")
+}
+
+func TestNilParamsMarketSummary(t *testing.T) {
+	quotes, err := GetP(nil)
+
+	assert.Nil(t, quotes)
+	assert.Equal(t, "code: api-error, detail: missing function argument", err.Error())
+}
@@ -0,0 +1,57 @@
+package finance
+
+// minorUnits maps the currency codes yahoo uses for prices
+// quoted in minor units to their major currency. London
+// stocks, for example, are quoted in pence (GBp), not pounds.
+var minorUnits = map[string]string{
+	"GBp": "GBP",
+	"GBX": "GBP",
+	"ZAc": "ZAR",
+	"ILA": "ILS",
+}
+
+// Money is an amount in a currency. Currency is the code yahoo
+// reports, which is case sensitive: GBp is pence, GBP is pounds.
+type Money struct {
+	Amount   float64
+	Currency string
+}
+
+// IsMinorUnit reports whether the amount is
+// in a minor unit of currency, such as pence.
+func (m Money) IsMinorUnit() bool {
+	_, ok := minorUnits[m.Currency]
+	return ok
+}
+
+// Major returns the amount converted to the major unit of its
+// currency, such as GBp to GBP. Other amounts are returned as is.
+func (m Money) Major() Money {
+	if major, ok := minorUnits[m.Currency]; ok {
+		return Money{Amount: m.Amount / 100, Currency: major}
+	}
+	return m
+}
+
+// QuotePrices are the prices of a quote in its currency.
+type QuotePrices struct {
+	RegularMarketPrice Money
+	Bid                Money
+	Ask                Money
+}
+
+// Money returns an amount in the quote's currency.
+func (q *Quote) Money(amount float64) Money {
+	return Money{Amount: amount, Currency: q.CurrencyID}
+}
+
+// Prices returns the regular market price, bid and ask of the quote
+// in its currency, so that amounts in different currencies, or in
+// pence rather than pounds, aren't mixed up.
+func (q *Quote) Prices() QuotePrices {
+	return QuotePrices{
+		RegularMarketPrice: q.Money(q.RegularMarketPrice),
+		Bid:                q.Money(q.Bid),
+		Ask:                q.Money(q.Ask),
+	}
+}
@@ -1,9 +1,12 @@
 package mutualfund
 
 import (
+	"context"
+	"net/http"
 	"testing"
 
 	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/financetest"
 	tests "github.com/fijoyapp/finance-go/testing"
 	"github.com/stretchr/testify/assert"
 )
@@ -56,3 +59,78 @@ func TestGetBadMutualFund(t *testing.T) {
 	assert.Nil(t, q)
 	assert.Nil(t, err)
 }
+
+func TestGetMutualFundProfile(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v10/finance/quoteSummary/INPSX", r.URL.Path)
+		assert.Equal(t, "fundProfile,fundPerformance", r.URL.Query().Get("modules"))
+		w.Write([]byte(`{"quoteSummary":{"result":[{
+			"fundProfile":{"family":"ProFunds","categoryName":"Technology",
+				"feesExpensesInvestment":{"annualReportExpenseRatio":0.0146}},
+			"fundPerformance":{
+				"trailingReturns":{"ytd":0.0812,"oneYear":0.3215},
+				"annualTotalReturns":{"returns":[{"year":"2022","annualValue":-0.4521},{"year":"2023","annualValue":0.5604}]}
+			}
+		}]}}`))
+	}))
+	c := Client{B: backend}
+
+	profile, err := c.GetProfile("INPSX")
+
+	assert.Nil(t, err)
+	assert.NotNil(t, profile)
+	assert.Equal(t, "INPSX", profile.Symbol)
+	assert.Equal(t, 0.0146, *profile.ExpenseRatio)
+	assert.NotNil(t, profile.TrailingReturns)
+	assert.Len(t, profile.AnnualTotalReturns, 2)
+	assert.Equal(t, 2023, profile.AnnualTotalReturns[0].Year)
+	assert.Equal(t, 0.5604, *profile.AnnualTotalReturns[0].Return)
+}
+
+func TestGetMutualFundProfileContext(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent with a canceled context")
+	}))
+	c := Client{B: backend}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p := &ProfileParams{Symbol: "VFIAX"}
+	p.Context = &ctx
+
+	profile, err := c.GetProfileP(p)
+	assert.Nil(t, profile)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGetMutualFundProfileNoSymbol(t *testing.T) {
+	profile, err := GetProfile("")
+
+	assert.Nil(t, profile)
+	assert.Equal(t, "code: api-error, detail: missing function argument", err.Error())
+}
+
+func TestNewMutualFundProfile(t *testing.T) {
+	net, gross, ret := 0.005, 0.0075, 0.12
+	summary := &finance.QuoteSummary{
+		FundProfile: &finance.FundProfile{
+			CategoryName: "Large Growth",
+			Fees:         &finance.FundFees{NetExpenseRatio: &net, GrossExpenseRatio: &gross},
+		},
+		FundPerformance: &finance.FundPerformance{
+			AnnualTotalReturns: &finance.AnnualTotalReturns{Returns: []*finance.AnnualReturn{
+				{Year: 2021, Return: &ret},
+				{Year: 2023},
+			}},
+		},
+	}
+
+	profile := newProfile("TEST", summary)
+	assert.Equal(t, "Large Growth", profile.Category)
+	assert.Equal(t, 0.005, *profile.NetExpenseRatio)
+	assert.Equal(t, 0.0075, *profile.GrossExpenseRatio)
+	assert.Nil(t, profile.ExpenseRatio)
+	assert.Equal(t, 2023, profile.AnnualTotalReturns[0].Year)
+	assert.Nil(t, profile.AnnualTotalReturns[0].Return)
+	assert.Equal(t, 0.12, *profile.AnnualTotalReturns[1].Return)
+}
@@ -0,0 +1,83 @@
+package mutualfund
+
+import (
+	"sort"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/quotesummary"
+)
+
+// ProfileParams carries a context and symbol information.
+type ProfileParams struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Symbol string `form:"-"`
+}
+
+// GetProfile returns the profile and performance of a mutual fund.
+func GetProfile(symbol string) (*finance.MutualFundProfile, error) {
+	return GetProfileP(&ProfileParams{Symbol: symbol})
+}
+
+// GetProfileP returns the profile and performance of a mutual fund
+// and requires a params struct as an argument.
+func GetProfileP(params *ProfileParams) (*finance.MutualFundProfile, error) {
+	return getC().GetProfileP(params)
+}
+
+// GetProfile returns the profile and performance of a mutual fund.
+func (c Client) GetProfile(symbol string) (*finance.MutualFundProfile, error) {
+	return c.GetProfileP(&ProfileParams{Symbol: symbol})
+}
+
+// GetProfileP returns the profile and performance of a mutual fund.
+func (c Client) GetProfileP(params *ProfileParams) (*finance.MutualFundProfile, error) {
+	if params == nil || len(params.Symbol) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	summary, err := quotesummary.Client{B: c.B}.GetP(&quotesummary.Params{
+		Params:  params.Params,
+		Symbol:  params.Symbol,
+		Modules: []string{quotesummary.ModuleFundProfile, quotesummary.ModuleFundPerformance},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newProfile(params.Symbol, summary), nil
+}
+
+// newProfile builds a mutual fund profile from quote summary modules.
+func newProfile(symbol string, summary *finance.QuoteSummary) *finance.MutualFundProfile {
+	profile := &finance.MutualFundProfile{
+		Symbol:             symbol,
+		AnnualTotalReturns: []*finance.AnnualReturn{},
+	}
+
+	if fp := summary.FundProfile; fp != nil {
+		profile.Family = fp.Family
+		profile.Category = fp.CategoryName
+		if fp.Fees != nil {
+			profile.ExpenseRatio = fp.Fees.AnnualReportExpenseRatio
+			profile.NetExpenseRatio = fp.Fees.NetExpenseRatio
+			profile.GrossExpenseRatio = fp.Fees.GrossExpenseRatio
+			profile.Turnover = fp.Fees.AnnualHoldingsTurnover
+			profile.TotalNetAssets = fp.Fees.TotalNetAssets
+		}
+	}
+
+	if perf := summary.FundPerformance; perf != nil {
+		profile.TrailingReturns = perf.TrailingReturns
+		if perf.AnnualTotalReturns != nil {
+			profile.AnnualTotalReturns = append(profile.AnnualTotalReturns, perf.AnnualTotalReturns.Returns...)
+		}
+	}
+	sort.Slice(profile.AnnualTotalReturns, func(i, j int) bool {
+		return profile.AnnualTotalReturns[i].Year > profile.AnnualTotalReturns[j].Year
+	})
+
+	return profile
+}
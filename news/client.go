@@ -0,0 +1,90 @@
+package news
+
+import (
+	"sort"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/search"
+)
+
+// Client is used to invoke news APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and symbols information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Symbols []string `form:"-"`
+	// Count limits the number of articles
+	// fetched for each symbol.
+	Count int `form:"-"`
+}
+
+// Get returns the latest news articles for a symbol.
+func Get(symbol string) ([]finance.NewsArticle, error) {
+	return GetP(&Params{Symbols: []string{symbol}})
+}
+
+// GetList returns the latest news articles for several symbols.
+func GetList(symbols []string) ([]finance.NewsArticle, error) {
+	return GetP(&Params{Symbols: symbols})
+}
+
+// GetP returns news articles and requires a params
+// struct as an argument.
+func GetP(params *Params) ([]finance.NewsArticle, error) {
+	return getC().GetP(params)
+}
+
+// GetP returns news articles, newest first. Articles
+// related to several of the symbols are only listed once.
+func (c Client) GetP(params *Params) ([]finance.NewsArticle, error) {
+
+	if params == nil || len(params.Symbols) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	var articles []finance.NewsArticle
+	seen := map[string]bool{}
+
+	for _, symbol := range params.Symbols {
+		result, err := search.Client{B: c.B}.SearchP(&search.Params{
+			Params:    params.Params,
+			Query:     symbol,
+			NewsCount: params.Count,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, n := range result.News {
+			if seen[n.UUID] {
+				continue
+			}
+			seen[n.UUID] = true
+
+			articles = append(articles, finance.NewsArticle{
+				UUID:           n.UUID,
+				Title:          n.Title,
+				Publisher:      n.Publisher,
+				Link:           n.Link,
+				PublishTime:    n.ProviderPublishTime,
+				RelatedTickers: n.RelatedTickers,
+			})
+		}
+	}
+
+	sort.SliceStable(articles, func(i, j int) bool {
+		return articles[i].PublishTime.Unix() > articles[j].PublishTime.Unix()
+	})
+
+	return articles, nil
+}
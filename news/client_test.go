@@ -0,0 +1,56 @@
+package news
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient returns a client for a server responding
+// to searches for each symbol with the given news.
+func newTestClient(t *testing.T, news map[string]string) Client {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"quotes":[],"news":[` + news[r.URL.Query().Get("q")] + `]}`))
+	}))
+	return Client{B: backend}
+}
+
+func TestGetNews(t *testing.T) {
+	c := newTestClient(t, map[string]string{
+		"AAPL": `{"uuid":"a","title":"Older","providerPublishTime":1700000000},
+			{"uuid":"b","title":"Newer","providerPublishTime":1700003600}`,
+	})
+
+	articles, err := c.GetP(&Params{Symbols: []string{"AAPL"}})
+
+	assert.Nil(t, err)
+	assert.Len(t, articles, 2)
+	assert.Equal(t, "b", articles[0].UUID)
+	assert.Equal(t, "a", articles[1].UUID)
+	assert.Equal(t, 1700003600, articles[0].PublishTime.Unix())
+}
+
+func TestGetNewsList(t *testing.T) {
+	c := newTestClient(t, map[string]string{
+		"AAPL": `{"uuid":"a","providerPublishTime":1700000000,"relatedTickers":["AAPL","SPY"]}`,
+		"SPY": `{"uuid":"a","providerPublishTime":1700000000,"relatedTickers":["AAPL","SPY"]},
+			{"uuid":"c","providerPublishTime":1700007200,"relatedTickers":["SPY"]}`,
+	})
+
+	articles, err := c.GetP(&Params{Symbols: []string{"AAPL", "SPY"}})
+
+	assert.Nil(t, err)
+	assert.Len(t, articles, 2)
+	assert.Equal(t, "c", articles[0].UUID)
+	assert.Equal(t, "a", articles[1].UUID)
+	assert.Equal(t, []string{"AAPL", "SPY"}, articles[1].RelatedTickers)
+}
+
+func TestNilParamsNews(t *testing.T) {
+	articles, err := GetList(nil)
+
+	assert.Nil(t, articles)
+	assert.Equal(t, "code: api-error, detail: missing function argument", err.Error())
+}
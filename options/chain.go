@@ -0,0 +1,105 @@
+package options
+
+import (
+	"context"
+	"encoding/json"
+
+	finance "github.com/fijoyapp/finance-go"
+	form "github.com/fijoyapp/finance-go/form"
+	"github.com/fijoyapp/finance-go/iter"
+)
+
+// ContractIter is a structure containing results
+// and related metadata for a
+// yfin option chain request.
+type ContractIter struct {
+	*iter.Iter
+}
+
+// Contract returns the current contract in the iter.
+func (ci *ContractIter) Contract() *finance.Contract {
+	return ci.Current().(*finance.Contract)
+}
+
+// Meta returns the metadata associated with the options response.
+// It is nil if the request failed.
+func (ci *ContractIter) Meta() *finance.OptionsMeta {
+	m, _ := ci.Iter.Meta().(*finance.OptionsMeta)
+	return m
+}
+
+// GetChain returns the calls and then the puts of an options chain
+// and requires a params struct as an argument.
+func GetChain(params *Params) *ContractIter {
+	return getC().GetChain(params)
+}
+
+// GetChain returns the calls and then the puts of an options chain.
+// The nearest expiration is used unless params set an Expiration.
+func (c Client) GetChain(params *Params) *ContractIter {
+
+	if params == nil || len(params.UnderlyingSymbol) == 0 {
+		return &ContractIter{iter.NewE(finance.CreateArgumentError())}
+	}
+
+	if params.Context == nil {
+		ctx := context.TODO()
+		params.Context = &ctx
+	}
+
+	params.straddle = false
+	params.date = 0
+	if params.Expiration != nil {
+		params.date = expirationDate(params.Expiration)
+	}
+
+	body := &form.Values{}
+	form.AppendTo(body, params)
+
+	return &ContractIter{iter.New(body, func(b *form.Values) (meta interface{}, values []interface{}, err error) {
+
+		resp := response{}
+		err = c.B.Call(finance.YOptionsPrefix+params.UnderlyingSymbol, body, params.Context, &resp)
+		if err != nil {
+			return
+		}
+
+		if resp.Inner.Error != nil {
+			err = resp.Inner.Error
+			return
+		}
+
+		if len(resp.Inner.Results) == 0 || resp.Inner.Results[0] == nil {
+			err = finance.CreateRemoteErrorS("no results in option chain response")
+			return
+		}
+		result := resp.Inner.Results[0]
+
+		var list []chainOptions
+		err = json.Unmarshal(result.Options, &list)
+		if err != nil || len(list) < 1 {
+			err = finance.CreateRemoteErrorS("no results in option chain response")
+			return
+		}
+		lc := list[0]
+
+		meta = &finance.OptionsMeta{
+			UnderlyingSymbol:   result.UnderlyingSymbol,
+			ExpirationDate:     lc.ExpirationDate,
+			AllExpirationDates: result.ExpirationDates,
+			Strikes:            result.Strikes,
+			HasMiniOptions:     lc.HasMiniOptions,
+			Quote:              result.Quote,
+		}
+		for _, call := range lc.Calls {
+			call.Type = finance.ContractTypeCall
+			values = append(values, call)
+		}
+		for _, put := range lc.Puts {
+			put.Type = finance.ContractTypePut
+			values = append(values, put)
+		}
+
+		return
+	})}
+}
@@ -3,6 +3,7 @@ package options
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	finance "github.com/fijoyapp/finance-go"
 	"github.com/fijoyapp/finance-go/datetime"
@@ -44,8 +45,36 @@ func (si *StraddleIter) Straddle() *finance.Straddle {
 }
 
 // Meta returns the metadata associated with the options response.
+// It is nil if the request failed.
 func (si *StraddleIter) Meta() *finance.OptionsMeta {
-	return si.Iter.Meta().(*finance.OptionsMeta)
+	m, _ := si.Iter.Meta().(*finance.OptionsMeta)
+	return m
+}
+
+// GetExpirations returns all available expiration
+// dates for options on an underlier.
+func GetExpirations(underlier string) ([]datetime.Datetime, error) {
+	return getC().GetExpirations(underlier)
+}
+
+// GetExpirations returns all available expiration
+// dates for options on an underlier.
+func (c Client) GetExpirations(underlier string) ([]datetime.Datetime, error) {
+	iter := c.GetStraddleP(&Params{UnderlyingSymbol: underlier})
+	if iter.Err() != nil {
+		return nil, iter.Err()
+	}
+
+	meta := iter.Meta()
+	if meta == nil {
+		return nil, finance.CreateRemoteErrorS("no results in option straddle response")
+	}
+
+	expirations := make([]datetime.Datetime, len(meta.AllExpirationDates))
+	for i, e := range meta.AllExpirationDates {
+		expirations[i] = *datetime.FromUnix(e)
+	}
+	return expirations, nil
 }
 
 // GetStraddle returns options straddles.
@@ -54,6 +83,22 @@ func GetStraddle(underlier string) *StraddleIter {
 	return GetStraddleP(&Params{UnderlyingSymbol: underlier})
 }
 
+// GetStraddleAt returns options straddles for a
+// specific expiration, such as one from GetExpirations.
+func GetStraddleAt(underlier string, expiration datetime.Datetime) *StraddleIter {
+	return getC().GetStraddleAt(underlier, expiration)
+}
+
+// GetStraddleAt returns options straddles for a specific expiration.
+// Expirations built from date fields are requested as that day's
+// expiration, which yahoo keys by midnight UTC.
+func (c Client) GetStraddleAt(underlier string, expiration datetime.Datetime) *StraddleIter {
+	return c.GetStraddleP(&Params{
+		UnderlyingSymbol: underlier,
+		Expiration:       datetime.FromUnix(expirationDate(&expiration)),
+	})
+}
+
 // GetStraddleP returns options straddles.
 // and requires a params struct as an argument.
 func GetStraddleP(params *Params) *StraddleIter {
@@ -97,6 +142,11 @@ func (c Client) GetStraddleP(params *Params) *StraddleIter {
 			return
 		}
 
+		if len(resp.Inner.Results) == 0 {
+			err = finance.CreateRemoteErrorS("no results in option straddle response")
+			return
+		}
+
 		result := resp.Inner.Results[0]
 		if result == nil {
 			err = finance.CreateRemoteErrorS("no results in option straddle response")
@@ -127,6 +177,18 @@ func (c Client) GetStraddleP(params *Params) *StraddleIter {
 	})}
 }
 
+// expirationDate returns the unix timestamp yahoo uses for an
+// expiration, which is midnight UTC of the expiration day.
+// Dates already at midnight UTC, like those from GetExpirations,
+// are kept as is; otherwise the calendar date fields are used.
+func expirationDate(d *datetime.Datetime) int {
+	ts := d.Unix()
+	if ts%86400 == 0 {
+		return ts
+	}
+	return int(time.Date(d.Year, time.Month(d.Month), d.Day, 0, 0, 0, 0, time.UTC).Unix())
+}
+
 // response is a yfin option response.
 type response struct {
 	Inner struct {
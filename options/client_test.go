@@ -1,8 +1,16 @@
 package options
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/datetime"
+	"github.com/fijoyapp/finance-go/financetest"
 	tests "github.com/fijoyapp/finance-go/testing"
 	"github.com/stretchr/testify/assert"
 )
@@ -15,3 +23,330 @@ func TestGetStraddle(t *testing.T) {
 	assert.Nil(t, iter.Err())
 	assert.Equal(t, iter.Meta().UnderlyingSymbol, tests.TestStraddleSymbol)
 }
+
+// newTestClient returns a client for a server
+// responding with the given option chain result.
+func newTestClient(t *testing.T, result string) Client {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"optionChain":{"result":[%s],"error":null}}`, result)
+	}))
+	return Client{B: backend}
+}
+
+func TestGetExpirations(t *testing.T) {
+	c := newTestClient(t, `{
+		"underlyingSymbol":"AMD",
+		"expirationDates":[1531440000,1532044800,1534464000],
+		"strikes":[3,5],
+		"options":[{"expirationDate":1531440000,"straddles":[]}]
+	}`)
+
+	expirations, err := c.GetExpirations("AMD")
+	assert.Nil(t, err)
+	assert.Len(t, expirations, 3)
+	assert.Equal(t, 1531440000, expirations[0].Unix())
+	assert.Equal(t, 1534464000, expirations[2].Unix())
+}
+
+func TestGetExpirationsNoSymbol(t *testing.T) {
+	expirations, err := GetExpirations("")
+	assert.Nil(t, expirations)
+	assert.EqualError(t, err, "code: api-error, detail: missing function argument")
+}
+
+func TestGetStraddleAt(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1547769600", r.URL.Query().Get("date"))
+		w.Write([]byte(`{"optionChain":{"result":[{
+			"underlyingSymbol":"AMD",
+			"expirationDates":[1547164800,1547769600],
+			"options":[{"expirationDate":1547769600,"straddles":[{"strike":20}]}]
+		}],"error":null}}`))
+	}))
+	c := Client{B: backend}
+
+	// A local date is requested as that day's
+	// expiration, which is midnight UTC.
+	iter := c.GetStraddleAt("AMD", datetime.Datetime{Year: 2019, Month: 1, Day: 18})
+	assert.True(t, iter.Next())
+	assert.Nil(t, iter.Err())
+	assert.Equal(t, 1547769600, iter.Meta().ExpirationDate)
+}
+
+func TestGetStraddleExpiration(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1547769600", r.URL.Query().Get("date"))
+		w.Write([]byte(`{"optionChain":{"result":[{
+			"underlyingSymbol":"AMD",
+			"options":[{"expirationDate":1547769600,"straddles":[{"strike":20}]}]
+		}],"error":null}}`))
+	}))
+	c := Client{B: backend}
+
+	// GetStraddleP sends the expiration as is.
+	iter := c.GetStraddleP(&Params{UnderlyingSymbol: "AMD", Expiration: datetime.FromUnix(1547769600)})
+	assert.True(t, iter.Next())
+	assert.Nil(t, iter.Err())
+}
+
+func TestExpirationDate(t *testing.T) {
+	// Expirations are midnight UTC, whether they come
+	// from yahoo or are built from date fields.
+	assert.Equal(t, 1547769600, expirationDate(datetime.FromUnix(1547769600)))
+	assert.Equal(t, 1547769600, expirationDate(&datetime.Datetime{Year: 2019, Month: 1, Day: 18}))
+}
+
+func TestGetChain(t *testing.T) {
+	c := newTestClient(t, `{
+		"underlyingSymbol":"AMD",
+		"expirationDates":[1531440000],
+		"strikes":[3,5],
+		"options":[{"expirationDate":1531440000,
+			"calls":[{"contractSymbol":"AMD180713C00003000","strike":3},{"contractSymbol":"AMD180713C00005000","strike":5}],
+			"puts":[{"contractSymbol":"AMD180713P00003000","strike":3}]
+		}]
+	}`)
+
+	iter := c.GetChain(&Params{UnderlyingSymbol: "AMD"})
+
+	calls, puts := 0, 0
+	for iter.Next() {
+		c := iter.Contract()
+		switch c.Type {
+		case finance.ContractTypeCall:
+			assert.Zero(t, puts, "calls are yielded before puts")
+			calls++
+		case finance.ContractTypePut:
+			puts++
+		default:
+			t.Errorf("unexpected contract type %q", c.Type)
+		}
+	}
+	assert.Nil(t, iter.Err())
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 1, puts)
+	assert.Equal(t, "AMD", iter.Meta().UnderlyingSymbol)
+	assert.Equal(t, 1531440000, iter.Meta().ExpirationDate)
+}
+
+func TestGetChainNoSymbol(t *testing.T) {
+	iter := GetChain(nil)
+	assert.False(t, iter.Next())
+	assert.Nil(t, iter.Meta())
+	assert.EqualError(t, iter.Err(), "code: api-error, detail: missing function argument")
+}
+
+func TestGetIVSurface(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expiration, iv := "1531440000", 0.5
+		if r.URL.Query().Get("date") == "1532044800" {
+			expiration, iv = "1532044800", 0.4
+		}
+		fmt.Fprintf(w, `{"optionChain":{"result":[{
+			"underlyingSymbol":"AMD",
+			"expirationDates":[1531440000,1532044800],
+			"options":[{"expirationDate":%s,
+				"calls":[{"strike":5,"impliedVolatility":%v}],
+				"puts":[{"strike":5,"impliedVolatility":%v}]
+			}]
+		}],"error":null}}`, expiration, iv, iv+0.1)
+	}))
+	c := Client{B: backend}
+
+	surface, err := c.GetIVSurface("AMD")
+	assert.Nil(t, err)
+	assert.NotNil(t, surface)
+	assert.Equal(t, []int{1531440000, 1532044800}, surface.Expirations)
+	assert.Equal(t, []float64{5}, surface.Strikes)
+	assert.Equal(t, 0.5, *surface.Calls[0][0])
+	assert.Equal(t, 0.4, *surface.Calls[1][0])
+	assert.Equal(t, 0.6, *surface.Puts[0][0])
+}
+
+func TestNewIVSurfaceGaps(t *testing.T) {
+	chains := map[int][]*finance.Contract{
+		200: {
+			{Type: finance.ContractTypeCall, Strike: 10, ImpliedVolatility: 0.3},
+		},
+		100: {
+			{Type: finance.ContractTypeCall, Strike: 10, ImpliedVolatility: 0.2},
+			{Type: finance.ContractTypeCall, Strike: 5, ImpliedVolatility: 0.25},
+			{Type: finance.ContractTypePut, Strike: 5, ImpliedVolatility: 0.4},
+		},
+	}
+
+	surface := newIVSurface("TEST", chains)
+
+	assert.Equal(t, []int{100, 200}, surface.Expirations)
+	assert.Equal(t, []float64{5, 10}, surface.Strikes)
+	assert.Equal(t, 0.25, *surface.Calls[0][0])
+	assert.Equal(t, 0.2, *surface.Calls[0][1])
+	assert.Nil(t, surface.Calls[1][0])
+	assert.Equal(t, 0.3, *surface.Calls[1][1])
+	assert.Equal(t, 0.4, *surface.Puts[0][0])
+	assert.Nil(t, surface.Puts[0][1])
+	assert.Nil(t, surface.Puts[1][0])
+}
+
+func TestGetList(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		symbol := strings.TrimPrefix(r.URL.Path, finance.YOptionsPrefix)
+		if symbol == "BAD" {
+			fmt.Fprint(w, `{"optionChain":{"result":[],"error":null}}`)
+			return
+		}
+		assert.Equal(t, "true", r.URL.Query().Get("straddle"))
+		fmt.Fprintf(w, `{"optionChain":{"result":[{
+			"underlyingSymbol":%q,
+			"expirationDates":[1547769600],
+			"options":[{"expirationDate":1547769600,"straddles":[{"strike":20}]}]
+		}],"error":null}}`, symbol)
+	}))
+	c := Client{B: backend}
+
+	straddles, err := c.GetListP([]string{"AMD", "BAD", "INTC"}, nil)
+	assert.Len(t, straddles, 2)
+	assert.Equal(t, "INTC", straddles["INTC"].Meta().UnderlyingSymbol)
+	assert.True(t, straddles["AMD"].Next())
+	assert.Equal(t, 20.0, straddles["AMD"].Straddle().Strike)
+
+	assert.IsType(t, finance.ListError{}, err)
+	assert.EqualError(t, err, "1 request(s) failed: BAD: code: remote-error, detail: no results in option straddle response")
+}
+
+func TestGreeks(t *testing.T) {
+	// A year before the close on the expiration date.
+	now := time.Date(2023, 1, 2, 21, 0, 0, 0, time.UTC)
+	contract := finance.Contract{
+		Type:              finance.ContractTypeCall,
+		Strike:            100,
+		Expiration:        int(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC).Unix()),
+		ImpliedVolatility: 0.2,
+	}
+
+	g := greeks(contract, 100, 0.05, Actual365, now)
+	assert.InDelta(t, 0.6368, g.Delta, 1e-4)
+	assert.InDelta(t, 0.01876, g.Gamma, 1e-5)
+	assert.InDelta(t, -6.414/365, g.Theta, 1e-5)
+	assert.InDelta(t, 0.3752, g.Vega, 1e-4)
+	assert.InDelta(t, 0.5323, g.Rho, 1e-4)
+
+	contract.Type = finance.ContractTypePut
+	g = greeks(contract, 100, 0.05, Actual365, now)
+	assert.InDelta(t, -0.3632, g.Delta, 1e-4)
+	assert.InDelta(t, 0.01876, g.Gamma, 1e-5)
+	assert.InDelta(t, -1.658/365, g.Theta, 1e-5)
+	assert.InDelta(t, -0.4189, g.Rho, 1e-4)
+
+	// Expired contracts only carry their intrinsic delta.
+	assert.Equal(t, finance.Greeks{Delta: -1}, greeks(contract, 90, 0.05, Actual365, now.AddDate(2, 0, 0)))
+	assert.Equal(t, finance.Greeks{}, greeks(contract, 110, 0.05, Actual365, now.AddDate(2, 0, 0)))
+}
+
+func TestDayCount(t *testing.T) {
+	monday := time.Date(2024, 6, 3, 14, 0, 0, 0, time.UTC)
+	friday := expirationClose(int(time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC).Unix()))
+	assert.Equal(t, time.Date(2024, 6, 14, 20, 0, 0, 0, time.UTC), friday.UTC())
+	assert.InDelta(t, 9.25/252, Trading252.years(monday, friday), 1e-9)
+	assert.InDelta(t, 11.25/365, Actual365.years(monday, friday), 1e-9)
+
+	// Contracts expiring today have until the close left.
+	morning := time.Date(2024, 6, 14, 14, 0, 0, 0, time.UTC)
+	assert.InDelta(t, 0.25/252, Trading252.years(morning, friday), 1e-9)
+	assert.Equal(t, 0.0, Trading252.years(friday.Add(time.Minute), friday))
+}
+
+func TestGreeksExpiringToday(t *testing.T) {
+	expiration := time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)
+	contract := finance.Contract{
+		Type:              finance.ContractTypeCall,
+		Strike:            100,
+		Expiration:        int(expiration.Unix()),
+		ImpliedVolatility: 0.2,
+	}
+
+	g := greeks(contract, 100, 0.05, Trading252, expiration.Add(14*time.Hour))
+	assert.InDelta(t, 0.5, g.Delta, 0.01)
+	assert.True(t, g.Gamma > 0)
+	assert.True(t, g.Theta < 0)
+}
+
+func TestVerticalSpreads(t *testing.T) {
+	contract := func(typ finance.ContractType, strike, bid, ask float64) *finance.Contract {
+		return &finance.Contract{Type: typ, Strike: strike, Bid: bid, Ask: ask}
+	}
+
+	calls := []*finance.Contract{
+		contract(finance.ContractTypeCall, 105, 1.0, 1.2),
+		contract(finance.ContractTypeCall, 100, 3.0, 3.2),
+		contract(finance.ContractTypeCall, 90, 0, 0),
+	}
+	spreads := verticalSpreads(calls, &SpreadParams{})
+	assert.Len(t, spreads, 2)
+
+	bull := spreads[0]
+	assert.Equal(t, finance.SpreadTypeBullCall, bull.Type)
+	assert.Equal(t, 100.0, bull.Long.Strike)
+	assert.Equal(t, 105.0, bull.Short.Strike)
+	assert.Equal(t, 5.0, bull.Width)
+	assert.InDelta(t, -2.2, bull.Net, 1e-9)
+	assert.InDelta(t, 2.8, bull.MaxProfit, 1e-9)
+	assert.InDelta(t, 2.2, bull.MaxLoss, 1e-9)
+	assert.InDelta(t, 102.2, bull.Breakeven, 1e-9)
+
+	bear := spreads[1]
+	assert.Equal(t, finance.SpreadTypeBearCall, bear.Type)
+	assert.InDelta(t, 1.8, bear.Net, 1e-9)
+	assert.InDelta(t, 1.8, bear.MaxProfit, 1e-9)
+	assert.InDelta(t, 3.2, bear.MaxLoss, 1e-9)
+	assert.InDelta(t, 101.8, bear.Breakeven, 1e-9)
+
+	puts := []*finance.Contract{
+		contract(finance.ContractTypePut, 95, 1.0, 1.1),
+		contract(finance.ContractTypePut, 100, 2.5, 2.6),
+		contract(finance.ContractTypePut, 110, 9.0, 9.5),
+	}
+	spreads = verticalSpreads(puts, &SpreadParams{MinCredit: 1, MaxWidth: 5})
+	assert.Len(t, spreads, 1)
+	assert.Equal(t, finance.SpreadTypeBullPut, spreads[0].Type)
+	assert.Equal(t, 100.0, spreads[0].Short.Strike)
+	assert.InDelta(t, 1.4, spreads[0].Net, 1e-9)
+	assert.InDelta(t, 98.6, spreads[0].Breakeven, 1e-9)
+}
+
+func TestGetIVSurfaceGaps(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		date := r.URL.Query().Get("date")
+		if date == "1548374400" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if date == "" || date == "0" {
+			date = "1547769600"
+		}
+		fmt.Fprintf(w, `{"optionChain":{"result":[{
+			"underlyingSymbol":"AMD",
+			"expirationDates":[1547769600,1548374400,1548979200],
+			"options":[{"expirationDate":%s,"calls":[{"strike":20,"impliedVolatility":0.5}]}]
+		}],"error":null}}`, date)
+	}))
+	c := Client{B: backend}
+
+	surface, err := c.GetIVSurfaceP(&Params{UnderlyingSymbol: "AMD"})
+	assert.IsType(t, finance.ListError{}, err)
+	assert.Contains(t, err.(finance.ListError), "2019-01-25")
+	assert.Len(t, err.(finance.ListError), 1)
+
+	assert.Equal(t, []int{1547769600, 1548374400, 1548979200}, surface.Expirations)
+	assert.Equal(t, 0.5, *surface.Calls[0][0])
+	assert.Nil(t, surface.Calls[1][0])
+	assert.Equal(t, 0.5, *surface.Calls[2][0])
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p := &Params{UnderlyingSymbol: "AMD"}
+	p.Context = &ctx
+	surface, err = c.GetIVSurfaceP(p)
+	assert.Nil(t, surface)
+	assert.ErrorIs(t, err, context.Canceled)
+}
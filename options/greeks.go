@@ -0,0 +1,125 @@
+package options
+
+import (
+	"math"
+	"time"
+
+	finance "github.com/fijoyapp/finance-go"
+)
+
+// DayCount is a convention for measuring the time to
+// expiry of a contract as a fraction of a year.
+type DayCount int
+
+const (
+	// Trading252 counts the weekdays until expiration
+	// over a year of 252 trading days.
+	Trading252 DayCount = iota
+	// Actual365 counts the calendar days until
+	// expiration over a year of 365 days.
+	Actual365
+)
+
+// daysPerYear returns the number of days in a year.
+func (dc DayCount) daysPerYear() float64 {
+	if dc == Actual365 {
+		return 365
+	}
+	return 252
+}
+
+// years returns the time from now until expiry as a fraction of a
+// year. Partial days count as fractions, and Trading252 leaves out
+// the time falling on weekends.
+func (dc DayCount) years(now, expiry time.Time) float64 {
+	if !expiry.After(now) {
+		return 0
+	}
+	if dc == Actual365 {
+		return expiry.Sub(now).Hours() / 24 / dc.daysPerYear()
+	}
+
+	var weekdays time.Duration
+	for d := now.In(expiry.Location()); d.Before(expiry); {
+		year, month, day := d.Date()
+		next := time.Date(year, month, day+1, 0, 0, 0, 0, d.Location())
+		if next.After(expiry) {
+			next = expiry
+		}
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			weekdays += next.Sub(d)
+		}
+		d = next
+	}
+	return weekdays.Hours() / 24 / dc.daysPerYear()
+}
+
+// expirationClose returns when a contract expiring on the date of
+// expiration stops trading, at the 4pm close in New York.
+func expirationClose(expiration int) time.Time {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		loc = time.FixedZone("EST", -5*60*60)
+	}
+	year, month, day := time.Unix(int64(expiration), 0).UTC().Date()
+	return time.Date(year, month, day, 16, 0, 0, 0, loc)
+}
+
+// Greeks returns the Black-Scholes greeks of an option contract from
+// its strike, expiration, type and implied volatility, given the price
+// of the underlier and the annual risk-free rate, such as 0.05 for 5%.
+// The time to expiry runs until the close on the expiration date and
+// is measured with dayCount. Dividends are not accounted for. Expired
+// contracts, or ones without a volatility, only carry a delta of 1 or
+// -1 if they are in the money.
+func Greeks(contract finance.Contract, underlyingPrice, riskFreeRate float64, dayCount DayCount) finance.Greeks {
+	return greeks(contract, underlyingPrice, riskFreeRate, dayCount, time.Now())
+}
+
+// greeks returns the greeks of a contract as of now.
+func greeks(contract finance.Contract, s, r float64, dc DayCount, now time.Time) finance.Greeks {
+	k, sigma := contract.Strike, contract.ImpliedVolatility
+	t := dc.years(now, expirationClose(contract.Expiration))
+	call := contract.Type != finance.ContractTypePut
+
+	if t <= 0 || sigma <= 0 || s <= 0 || k <= 0 {
+		switch {
+		case call && s > k:
+			return finance.Greeks{Delta: 1}
+		case !call && s < k:
+			return finance.Greeks{Delta: -1}
+		}
+		return finance.Greeks{}
+	}
+
+	sqrtT := math.Sqrt(t)
+	d1 := (math.Log(s/k) + (r+sigma*sigma/2)*t) / (sigma * sqrtT)
+	d2 := d1 - sigma*sqrtT
+	discount := k * math.Exp(-r*t)
+	decay := -s * normPDF(d1) * sigma / (2 * sqrtT)
+
+	g := finance.Greeks{
+		Gamma: normPDF(d1) / (s * sigma * sqrtT),
+		Vega:  s * normPDF(d1) * sqrtT / 100,
+	}
+	if call {
+		g.Delta = normCDF(d1)
+		g.Theta = (decay - r*discount*normCDF(d2)) / dc.daysPerYear()
+		g.Rho = discount * t * normCDF(d2) / 100
+	} else {
+		g.Delta = normCDF(d1) - 1
+		g.Theta = (decay + r*discount*normCDF(-d2)) / dc.daysPerYear()
+		g.Rho = -discount * t * normCDF(-d2) / 100
+	}
+	return g
+}
+
+// normCDF is the standard normal cumulative distribution function.
+func normCDF(x float64) float64 {
+	return math.Erfc(-x/math.Sqrt2) / 2
+}
+
+// normPDF is the standard normal probability density function.
+func normPDF(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
@@ -0,0 +1,47 @@
+package options
+
+import (
+	finance "github.com/fijoyapp/finance-go"
+)
+
+// ListConcurrency is the number of straddle
+// requests made at once by GetList.
+const ListConcurrency = 5
+
+// GetList returns options straddles for many underliers
+// fetched concurrently, keyed by symbol.
+func GetList(underliers []string) (map[string]*StraddleIter, error) {
+	return getC().GetListP(underliers, nil)
+}
+
+// GetListP returns options straddles for many underliers fetched
+// concurrently, keyed by symbol, and accepts a params struct
+// for the context and expiration shared by every request.
+func GetListP(underliers []string, params *Params) (map[string]*StraddleIter, error) {
+	return getC().GetListP(underliers, params)
+}
+
+// GetListP returns options straddles for many underliers fetched
+// concurrently, keyed by symbol. The UnderlyingSymbol field of params
+// is ignored. Underliers that fail are left out of the returned map
+// and reported together in a finance.ListError.
+func (c Client) GetListP(underliers []string, params *Params) (map[string]*StraddleIter, error) {
+
+	if params == nil {
+		params = &Params{}
+	}
+
+	results, failed := finance.FetchConcurrently(underliers, ListConcurrency, func(underlier string) (*StraddleIter, error) {
+		// GetStraddleP mutates its params, so
+		// each request works on its own copy.
+		p := *params
+		p.UnderlyingSymbol = underlier
+		it := c.GetStraddleP(&p)
+		return it, it.Err()
+	})
+
+	if len(failed) > 0 {
+		return results, finance.ListError(failed)
+	}
+	return results, nil
+}
@@ -0,0 +1,131 @@
+package options
+
+import (
+	"math"
+	"sort"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/datetime"
+)
+
+// SpreadParams carries a context and the
+// filters of a vertical spread search.
+type SpreadParams struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Expiration of the chain to search.
+	// The nearest expiration is used if nil.
+	Expiration *datetime.Datetime `form:"-"`
+	// MinCredit, if set, only keeps credit spreads
+	// receiving at least this much per share.
+	MinCredit float64 `form:"-"`
+	// MaxWidth, if set, only keeps spreads
+	// whose strikes are at most this far apart.
+	MaxWidth float64 `form:"-"`
+}
+
+// FindVerticalSpreads returns the candidate bull and bear call and put
+// spreads of an options chain of the underlier, and accepts a params
+// struct to filter them.
+func FindVerticalSpreads(underlier string, params *SpreadParams) ([]*finance.VerticalSpread, error) {
+	return getC().FindVerticalSpreads(underlier, params)
+}
+
+// FindVerticalSpreads returns the candidate bull and bear call and put
+// spreads of an options chain of the underlier. Spreads are priced from
+// the bid and ask of their legs, and those that can't be priced, or
+// have no possible profit or loss, are left out.
+func (c Client) FindVerticalSpreads(underlier string, params *SpreadParams) ([]*finance.VerticalSpread, error) {
+
+	if params == nil {
+		params = &SpreadParams{}
+	}
+
+	it := c.GetChain(&Params{
+		Params:           params.Params,
+		UnderlyingSymbol: underlier,
+		Expiration:       params.Expiration,
+	})
+
+	var calls, puts []*finance.Contract
+	for it.Next() {
+		contract := it.Contract()
+		if contract.Type == finance.ContractTypeCall {
+			calls = append(calls, contract)
+		} else {
+			puts = append(puts, contract)
+		}
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+
+	spreads := append(verticalSpreads(calls, params), verticalSpreads(puts, params)...)
+	return spreads, nil
+}
+
+// verticalSpreads returns the spreads between contracts of the same
+// type, bull spreads first, ordered by their lower and upper strikes.
+func verticalSpreads(contracts []*finance.Contract, params *SpreadParams) []*finance.VerticalSpread {
+	sorted := append([]*finance.Contract(nil), contracts...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Strike < sorted[j].Strike })
+
+	var bull, bear []*finance.VerticalSpread
+	for i, lower := range sorted {
+		for _, upper := range sorted[i+1:] {
+			width := upper.Strike - lower.Strike
+			if width <= 0 || (params.MaxWidth > 0 && width > params.MaxWidth) {
+				continue
+			}
+
+			if lower.Type == finance.ContractTypeCall {
+				bull = appendSpread(bull, params, finance.SpreadTypeBullCall, lower, upper, width, lower.Strike)
+				bear = appendSpread(bear, params, finance.SpreadTypeBearCall, upper, lower, width, lower.Strike)
+			} else {
+				bull = appendSpread(bull, params, finance.SpreadTypeBullPut, lower, upper, width, upper.Strike)
+				bear = appendSpread(bear, params, finance.SpreadTypeBearPut, upper, lower, width, upper.Strike)
+			}
+		}
+	}
+	return append(bull, bear...)
+}
+
+// appendSpread prices a spread buying long and selling short,
+// and appends it to spreads if it passes the filters of params.
+// The breakeven is taken from the strike the spread pivots on.
+func appendSpread(spreads []*finance.VerticalSpread, params *SpreadParams, typ finance.SpreadType,
+	long, short *finance.Contract, width, strike float64) []*finance.VerticalSpread {
+
+	if long.Ask <= 0 || short.Bid <= 0 {
+		return spreads
+	}
+
+	s := &finance.VerticalSpread{
+		Type:  typ,
+		Long:  long,
+		Short: short,
+		Width: width,
+		Net:   short.Bid - long.Ask,
+	}
+	if s.Net > 0 {
+		s.MaxProfit, s.MaxLoss = s.Net, width-s.Net
+	} else {
+		s.MaxProfit, s.MaxLoss = width+s.Net, -s.Net
+	}
+	if s.MaxProfit <= 0 || s.MaxLoss <= 0 {
+		return spreads
+	}
+
+	switch typ {
+	case finance.SpreadTypeBullCall, finance.SpreadTypeBearCall:
+		s.Breakeven = strike + math.Abs(s.Net)
+	default:
+		s.Breakeven = strike - math.Abs(s.Net)
+	}
+
+	if params.MinCredit > 0 && s.Net < params.MinCredit {
+		return spreads
+	}
+	return append(spreads, s)
+}
@@ -0,0 +1,141 @@
+package options
+
+import (
+	"sort"
+	"time"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/datetime"
+)
+
+// SurfaceConcurrency is the number of option chains
+// fetched at once by GetIVSurface.
+const SurfaceConcurrency = 5
+
+// GetIVSurface returns the implied volatility surface
+// across all expirations of the options on an underlier.
+func GetIVSurface(underlier string) (*finance.IVSurface, error) {
+	return GetIVSurfaceP(&Params{UnderlyingSymbol: underlier})
+}
+
+// GetIVSurfaceP returns the implied volatility surface
+// and requires a params struct as an argument.
+func GetIVSurfaceP(params *Params) (*finance.IVSurface, error) {
+	return getC().GetIVSurfaceP(params)
+}
+
+// GetIVSurface returns the implied volatility surface
+// across all expirations of the options on an underlier.
+func (c Client) GetIVSurface(underlier string) (*finance.IVSurface, error) {
+	return c.GetIVSurfaceP(&Params{UnderlyingSymbol: underlier})
+}
+
+// GetIVSurfaceP returns the implied volatility surface across all
+// expirations of the options on the underlier of params, whose context
+// is used for every chain request. The Expiration field is ignored.
+// Expirations whose chain can't be fetched are left as gaps in the
+// surface and reported together in a finance.ListError keyed by date.
+func (c Client) GetIVSurfaceP(params *Params) (*finance.IVSurface, error) {
+
+	if params == nil || len(params.UnderlyingSymbol) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	// The front chain also lists every expiration.
+	front := c.GetChain(&Params{Params: params.Params, UnderlyingSymbol: params.UnderlyingSymbol})
+	if front.Err() != nil {
+		return nil, front.Err()
+	}
+	meta := front.Meta()
+	if meta == nil {
+		return nil, finance.CreateRemoteErrorS("no results in option chain response")
+	}
+
+	chains := map[int][]*finance.Contract{}
+	for front.Next() {
+		chains[meta.ExpirationDate] = append(chains[meta.ExpirationDate], front.Contract())
+	}
+
+	pending := []int{}
+	for _, expiration := range meta.AllExpirationDates {
+		if _, ok := chains[expiration]; !ok {
+			pending = append(pending, expiration)
+		}
+	}
+
+	fetched, errs := finance.FetchConcurrently(pending, SurfaceConcurrency, func(expiration int) ([]*finance.Contract, error) {
+		it := c.GetChain(&Params{
+			Params:           params.Params,
+			UnderlyingSymbol: params.UnderlyingSymbol,
+			Expiration:       datetime.FromUnix(expiration),
+		})
+		contracts := []*finance.Contract{}
+		for it.Next() {
+			contracts = append(contracts, it.Contract())
+		}
+		return contracts, it.Err()
+	})
+	for expiration, contracts := range fetched {
+		chains[expiration] = contracts
+	}
+
+	// A failed chain is a gap in the surface.
+	failed := finance.ListError{}
+	for expiration, err := range errs {
+		chains[expiration] = nil
+		failed[expirationKey(expiration)] = err
+	}
+
+	surface := newIVSurface(meta.UnderlyingSymbol, chains)
+	if len(failed) > 0 {
+		return surface, failed
+	}
+	return surface, nil
+}
+
+// expirationKey returns the date of an expiration,
+// which is midnight UTC, as a finance.ListError key.
+func expirationKey(expiration int) string {
+	return time.Unix(int64(expiration), 0).UTC().Format("2006-01-02")
+}
+
+// newIVSurface builds a surface from option chains keyed by expiration.
+func newIVSurface(underlier string, chains map[int][]*finance.Contract) *finance.IVSurface {
+	surface := &finance.IVSurface{UnderlyingSymbol: underlier}
+
+	strikes := map[float64]bool{}
+	for expiration, contracts := range chains {
+		surface.Expirations = append(surface.Expirations, expiration)
+		for _, c := range contracts {
+			strikes[c.Strike] = true
+		}
+	}
+	for strike := range strikes {
+		surface.Strikes = append(surface.Strikes, strike)
+	}
+	sort.Ints(surface.Expirations)
+	sort.Float64s(surface.Strikes)
+
+	strikeIndex := make(map[float64]int, len(surface.Strikes))
+	for i, strike := range surface.Strikes {
+		strikeIndex[strike] = i
+	}
+
+	surface.Calls = make([][]*float64, len(surface.Expirations))
+	surface.Puts = make([][]*float64, len(surface.Expirations))
+	for i, expiration := range surface.Expirations {
+		surface.Calls[i] = make([]*float64, len(surface.Strikes))
+		surface.Puts[i] = make([]*float64, len(surface.Strikes))
+		for _, c := range chains[expiration] {
+			iv := c.ImpliedVolatility
+			switch c.Type {
+			case finance.ContractTypeCall:
+				surface.Calls[i][strikeIndex[c.Strike]] = &iv
+			case finance.ContractTypePut:
+				surface.Puts[i][strikeIndex[c.Strike]] = &iv
+			}
+		}
+	}
+
+	return surface
+}
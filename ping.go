@@ -0,0 +1,35 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fijoyapp/finance-go/form"
+)
+
+// pingSymbol is the symbol quoted by Ping.
+const pingSymbol = "SPY"
+
+// Ping checks that the yfin backend can be reached and authorized by
+// quoting a single well-known symbol. If no crumb can be obtained,
+// the returned error matches ErrUnauthorized. This is useful for
+// readiness probes.
+func Ping(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+
+	b := GetBackend(YFinBackend)
+	if s, ok := b.(*BackendConfiguration); ok && s.Type == YFinBackend {
+		if _, err := getCrumb(ctx, s.HTTPClient); err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			return fmt.Errorf("%w: cannot get yahoo crumb: %w", ErrUnauthorized, err)
+		}
+	}
+
+	body := &form.Values{}
+	body.Add("symbols", pingSymbol)
+	return b.Call(YQuotePath, body, &ctx, nil)
+}
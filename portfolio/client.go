@@ -0,0 +1,157 @@
+package portfolio
+
+import (
+	"errors"
+	"strings"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/forex"
+	"github.com/fijoyapp/finance-go/quote"
+)
+
+// DefaultBase is the default currency portfolios are valued in.
+const DefaultBase = "USD"
+
+// Client is used to value portfolios.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Holding is a quantity of a symbol held in a portfolio.
+type Holding struct {
+	Symbol   string
+	Quantity float64
+}
+
+// Position is a priced holding. Its amounts are in the base
+// currency of the valuation, while the quote is as returned
+// by yahoo, in the currency of the symbol.
+type Position struct {
+	Holding
+	Quote *finance.Quote
+	// Value is the quantity at the regular market price.
+	Value float64
+	// DayChange is the change in value since the previous close.
+	DayChange float64
+}
+
+// Valuation is the value of a portfolio in a base currency.
+type Valuation struct {
+	Base      string
+	Value     float64
+	DayChange float64
+	// Positions are the priced holdings,
+	// in the order they were given.
+	Positions []*Position
+	// Unpriced are the symbols that yahoo couldn't
+	// quote, or whose currency couldn't be converted.
+	Unpriced []string
+}
+
+// Params carries a context and the holdings to value.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Holdings []Holding `form:"-"`
+	// Base is the currency the portfolio is
+	// valued in. Defaults to DefaultBase.
+	Base string `form:"-"`
+}
+
+// Value returns the value of holdings in DefaultBase.
+func Value(holdings []Holding) (*Valuation, error) {
+	return ValueP(&Params{Holdings: holdings})
+}
+
+// ValueP returns the value of holdings and requires
+// a params struct as an argument.
+func ValueP(params *Params) (*Valuation, error) {
+	return getC().ValueP(params)
+}
+
+// ValueP returns the value of holdings, quoting them in a single
+// quote list and converting prices in other currencies, or in minor
+// units such as pence, to the base currency.
+func (c Client) ValueP(params *Params) (*Valuation, error) {
+	if params == nil || len(params.Holdings) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	base := strings.ToUpper(params.Base)
+	if base == "" {
+		base = DefaultBase
+	}
+
+	symbols := make([]string, len(params.Holdings))
+	for i, h := range params.Holdings {
+		symbols[i] = h.Symbol
+	}
+
+	quotes := map[string]*finance.Quote{}
+	it := quote.Client{B: c.B}.ListP(&quote.Params{Params: params.Params, Symbols: symbols})
+	for it.Next() {
+		q := it.Quote()
+		quotes[strings.ToUpper(q.Symbol)] = q
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+
+	currencies := []string{}
+	seen := map[string]bool{}
+	for _, h := range params.Holdings {
+		q, ok := quotes[strings.ToUpper(h.Symbol)]
+		if !ok {
+			continue
+		}
+		currency := q.Money(0).Major().Currency
+		if currency != "" && !seen[currency] {
+			seen[currency] = true
+			currencies = append(currencies, currency)
+		}
+	}
+
+	rates := map[string]float64{}
+	if len(currencies) > 0 {
+		var err error
+		rates, err = forex.Client{B: c.B}.RatesP(&forex.RatesParams{Params: params.Params, Base: base, Quotes: currencies})
+		var unavailable *forex.UnavailableError
+		if err != nil && !errors.As(err, &unavailable) {
+			return nil, err
+		}
+	}
+
+	v := &Valuation{Base: base, Positions: []*Position{}, Unpriced: []string{}}
+	for _, h := range params.Holdings {
+		q, ok := quotes[strings.ToUpper(h.Symbol)]
+		if !ok || q.RegularMarketPrice <= 0 {
+			v.Unpriced = append(v.Unpriced, h.Symbol)
+			continue
+		}
+
+		price := q.Money(q.RegularMarketPrice).Major()
+		rate, ok := rates[strings.ToUpper(price.Currency)]
+		if !ok || rate <= 0 {
+			v.Unpriced = append(v.Unpriced, h.Symbol)
+			continue
+		}
+
+		p := &Position{
+			Holding:   h,
+			Quote:     q,
+			Value:     h.Quantity * price.Amount / rate,
+			DayChange: h.Quantity * q.Money(q.RegularMarketChange).Major().Amount / rate,
+		}
+		v.Positions = append(v.Positions, p)
+		v.Value += p.Value
+		v.DayChange += p.DayChange
+	}
+
+	return v, nil
+}
@@ -0,0 +1,89 @@
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValue(t *testing.T) {
+	var requests []string
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		symbols := r.URL.Query().Get("symbols")
+		requests = append(requests, symbols)
+		switch symbols {
+		case "AAPL,VOD.L,BAD,AIR.PA":
+			fmt.Fprint(w, `{"quoteResponse":{"result":[
+				{"symbol":"VOD.L","currency":"GBp","regularMarketPrice":70,"regularMarketChange":-2},
+				{"symbol":"AAPL","currency":"USD","regularMarketPrice":200,"regularMarketChange":4},
+				{"symbol":"AIR.PA","currency":"EUR","regularMarketPrice":150,"regularMarketChange":1}
+			]}}`)
+		default:
+			fmt.Fprint(w, `{"quoteResponse":{"result":[
+				{"symbol":"USDGBP=X","regularMarketPrice":0.8}
+			]}}`)
+		}
+	}))
+	c := Client{B: backend}
+
+	v, err := c.ValueP(&Params{Holdings: []Holding{
+		{Symbol: "AAPL", Quantity: 10},
+		{Symbol: "VOD.L", Quantity: 1000},
+		{Symbol: "BAD", Quantity: 1},
+		{Symbol: "AIR.PA", Quantity: 5},
+	}})
+	assert.Nil(t, err)
+	assert.Len(t, requests, 2)
+	assert.Equal(t, "USD", v.Base)
+	assert.Equal(t, []string{"BAD", "AIR.PA"}, v.Unpriced)
+
+	assert.Len(t, v.Positions, 2)
+	assert.Equal(t, "AAPL", v.Positions[0].Symbol)
+	assert.InDelta(t, 2000, v.Positions[0].Value, 1e-9)
+	assert.InDelta(t, 40, v.Positions[0].DayChange, 1e-9)
+	assert.Equal(t, "VOD.L", v.Positions[1].Symbol)
+	assert.InDelta(t, 875, v.Positions[1].Value, 1e-9)
+	assert.InDelta(t, -25, v.Positions[1].DayChange, 1e-9)
+
+	assert.InDelta(t, 2875, v.Value, 1e-9)
+	assert.InDelta(t, 15, v.DayChange, 1e-9)
+}
+
+func TestValueForexContext(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Query().Get("symbols"), "=X") {
+			// The forex request hangs until the client gives up.
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		fmt.Fprint(w, `{"quoteResponse":{"result":[
+			{"symbol":"VOD.L","currency":"GBp","regularMarketPrice":70}
+		]}}`)
+	}))
+	c := Client{B: backend}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	p := &Params{Holdings: []Holding{{Symbol: "VOD.L", Quantity: 1}}}
+	p.Context = &ctx
+
+	start := time.Now()
+	_, err := c.ValueP(p)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestValueNoHoldings(t *testing.T) {
+	v, err := Value(nil)
+	assert.Nil(t, v)
+	assert.EqualError(t, err, "code: api-error, detail: missing function argument")
+}
@@ -0,0 +1,68 @@
+package profile
+
+import (
+	"errors"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/quotesummary"
+)
+
+// ErrNoProfile is returned for symbols without
+// a company profile, such as most funds.
+var ErrNoProfile = errors.New("no asset profile for symbol")
+
+// Client is used to invoke company profile APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and symbol information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Symbol string `form:"-"`
+}
+
+// Get returns the company profile for a symbol.
+func Get(symbol string) (*finance.AssetProfile, error) {
+	return GetP(&Params{Symbol: symbol})
+}
+
+// GetP returns a company profile and requires a params
+// struct as an argument.
+func GetP(params *Params) (*finance.AssetProfile, error) {
+	return getC().GetP(params)
+}
+
+// GetP returns a company profile.
+func (c Client) GetP(params *Params) (*finance.AssetProfile, error) {
+	if params == nil || len(params.Symbol) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	summary, err := quotesummary.Client{B: c.B}.GetP(&quotesummary.Params{
+		Params:  params.Params,
+		Symbol:  params.Symbol,
+		Modules: []string{quotesummary.ModuleAssetProfile},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	profile := summary.AssetProfile
+	if profile == nil {
+		return nil, ErrNoProfile
+	}
+	profile.Symbol = params.Symbol
+	if profile.Officers == nil {
+		profile.Officers = []*finance.CompanyOfficer{}
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,72 @@
+package profile
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProfile(t *testing.T) {
+	c := newTestClient(t, `{"assetProfile":{"sector":"Technology","industry":"Consumer Electronics","country":"United States"}}`)
+
+	profile, err := c.GetP(&Params{Symbol: "AAPL"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, profile)
+	assert.Equal(t, "AAPL", profile.Symbol)
+	assert.Equal(t, "Technology", profile.Sector)
+	assert.Equal(t, "United States", profile.Country)
+	assert.NotNil(t, profile.Officers)
+	assert.Empty(t, profile.Officers)
+}
+
+func TestGetProfileNoSymbol(t *testing.T) {
+	profile, err := Get("")
+	assert.Nil(t, profile)
+	assert.EqualError(t, err, "code: api-error, detail: missing function argument")
+}
+
+// newTestClient returns a client for a server
+// responding with the given quote summary result.
+func newTestClient(t *testing.T, result string) Client {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"quoteSummary":{"result":[%s]}}`, result)
+	}))
+	return Client{B: backend}
+}
+
+func TestGetProfileOfficers(t *testing.T) {
+	c := newTestClient(t, `{"assetProfile":{
+		"sector":"Technology",
+		"industry":"Consumer Electronics",
+		"fullTimeEmployees":161000,
+		"city":"Cupertino",
+		"website":"https://www.apple.com",
+		"companyOfficers":[
+			{"name":"Mr. Timothy D. Cook","title":"CEO & Director","age":62,"totalPay":{"raw":16425933,"fmt":"16.43M"}},
+			{"name":"Ms. Jane Doe","title":"VP","totalPay":{}}
+		]
+	}}`)
+
+	profile, err := c.GetP(&Params{Symbol: "AAPL"})
+	assert.Nil(t, err)
+	assert.Equal(t, "AAPL", profile.Symbol)
+	assert.Equal(t, "Technology", profile.Sector)
+	assert.Equal(t, 161000, profile.FullTimeEmployees)
+	assert.Len(t, profile.Officers, 2)
+	assert.Equal(t, "Mr. Timothy D. Cook", profile.Officers[0].Name)
+	assert.Equal(t, "CEO & Director", profile.Officers[0].Title)
+	assert.Equal(t, 16425933.0, profile.Officers[0].TotalPay)
+	assert.Zero(t, profile.Officers[1].TotalPay)
+}
+
+func TestGetProfileMissing(t *testing.T) {
+	c := newTestClient(t, `{}`)
+
+	profile, err := c.GetP(&Params{Symbol: "VTI"})
+	assert.Nil(t, profile)
+	assert.Equal(t, ErrNoProfile, err)
+}
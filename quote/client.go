@@ -2,6 +2,7 @@ package quote
 
 import (
 	"context"
+	"sort"
 	"strings"
 
 	finance "github.com/fijoyapp/finance-go"
@@ -26,9 +27,25 @@ type Params struct {
 	// Symbols are the symbols for which a
 	// quote is requested.
 	Symbols []string `form:"-"`
-	sym     string   `form:"symbols"`
+	// BatchSize is the maximum number of symbols sent
+	// in a single request. Defaults to DefaultBatchSize.
+	BatchSize int `form:"-"`
+	// Fields limits the response to the given quote fields,
+	// e.g. "regularMarketPrice". All fields are returned if empty.
+	Fields []string `form:"-"`
+
+	// Extra are additional query values, such as region or
+	// lang, which override those set by the params.
+	Extra *form.Values `form:"-"`
+
+	sym    string `form:"symbols"`
+	fields string `form:"fields"`
 }
 
+// DefaultBatchSize is the default number of symbols per quote request.
+// Yahoo truncates responses for requests with too many symbols.
+const DefaultBatchSize = 100
+
 // Iter is an iterator for a list of quotes.
 // The embedded Iter carries methods with it;
 // see its documentation for details.
@@ -60,7 +77,25 @@ func GetHistoricalQuote(symbol string, month int, day int, year int) (*finance.C
 
 // Get returns an Quote quote that matches the parameters specified.
 func Get(symbol string) (*finance.Quote, error) {
-	i := List([]string{symbol})
+	return GetContext(context.Background(), symbol)
+}
+
+// GetContext returns an Quote quote that matches the parameters
+// specified, using ctx for cancellation of the request.
+func GetContext(ctx context.Context, symbol string) (*finance.Quote, error) {
+	i := ListContext(ctx, []string{symbol})
+
+	if !i.Next() {
+		return nil, i.Err()
+	}
+
+	return i.Quote(), nil
+}
+
+// GetWithParams returns a quote for a symbol, adding extra
+// query values, such as region=GB, to the request.
+func GetWithParams(symbol string, extra *form.Values) (*finance.Quote, error) {
+	i := ListP(&Params{Symbols: []string{symbol}, Extra: extra})
 
 	if !i.Next() {
 		return nil, i.Err()
@@ -71,7 +106,15 @@ func Get(symbol string) (*finance.Quote, error) {
 
 // List returns several quotes.
 func List(symbols []string) *Iter {
-	return ListP(&Params{Symbols: symbols})
+	return ListContext(context.Background(), symbols)
+}
+
+// ListContext returns several quotes,
+// using ctx for cancellation of the request.
+func ListContext(ctx context.Context, symbols []string) *Iter {
+	p := &Params{Symbols: symbols}
+	p.Context = &ctx
+	return ListP(p)
 }
 
 // ListP returns a quote iterator and requires a params
@@ -83,41 +126,84 @@ func ListP(params *Params) *Iter {
 // ListP returns a quote iterator.
 func (c Client) ListP(params *Params) *Iter {
 
+	// Validate input.
+	// TODO: validate symbols..
+	if params == nil || len(params.Symbols) == 0 {
+		return &Iter{iter.NewE(finance.CreateArgumentError())}
+	}
+
 	if params.Context == nil {
 		ctx := context.TODO()
 		params.Context = &ctx
 	}
+	params.fields = strings.Join(params.Fields, ",")
 
-	// Validate input.
-	// TODO: validate symbols..
-	if params == nil || len(params.Symbols) == 0 {
-		return &Iter{iter.NewE(finance.CreateArgumentError())}
+	batchSize := params.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
 	}
-	params.sym = strings.Join(params.Symbols, ",")
 
-	body := &form.Values{}
-	form.AppendTo(body, params)
+	return &Iter{iter.New(nil, func(b *form.Values) (interface{}, []interface{}, error) {
 
-	return &Iter{iter.New(body, func(b *form.Values) (interface{}, []interface{}, error) {
+		quotes := []*finance.Quote{}
+		for start := 0; start < len(params.Symbols); start += batchSize {
+			end := start + batchSize
+			if end > len(params.Symbols) {
+				end = len(params.Symbols)
+			}
 
-		resp := response{}
-		err := c.B.Call(finance.YQuotePath, body, params.Context, &resp)
-		if err != nil {
-			err = finance.CreateRemoteError(err)
+			result, err := c.list(params, params.Symbols[start:end])
+			quotes = append(quotes, result...)
+			if err != nil {
+				return nil, toValues(quotes), err
+			}
 		}
 
-		ret := make([]interface{}, len(resp.Inner.Result))
-		for i, v := range resp.Inner.Result {
-			ret[i] = v
-		}
-		if resp.Inner.Error != nil {
-			err = finance.CreateRemoteError(resp.Inner.Error)
+		// Yahoo doesn't preserve the requested order.
+		order := make(map[string]int, len(params.Symbols))
+		for i, s := range params.Symbols {
+			if _, ok := order[strings.ToUpper(s)]; !ok {
+				order[strings.ToUpper(s)] = i
+			}
 		}
+		sort.SliceStable(quotes, func(i, j int) bool {
+			return order[strings.ToUpper(quotes[i].Symbol)] < order[strings.ToUpper(quotes[j].Symbol)]
+		})
 
-		return nil, ret, err
+		return nil, toValues(quotes), nil
 	})}
 }
 
+// list requests quotes for a single batch of symbols.
+func (c Client) list(params *Params, symbols []string) ([]*finance.Quote, error) {
+	params.sym = strings.Join(symbols, ",")
+
+	body := &form.Values{}
+	form.AppendTo(body, params)
+	finance.AppendLocale(body)
+	body.Merge(params.Extra)
+
+	resp := response{}
+	err := c.B.Call(finance.YQuotePath, body, params.Context, &resp)
+	if err != nil {
+		err = finance.CreateRemoteError(err)
+	}
+	if resp.Inner.Error != nil {
+		err = finance.CreateRemoteError(resp.Inner.Error)
+	}
+
+	return resp.Inner.Result, err
+}
+
+// toValues converts quotes to iterator values.
+func toValues(quotes []*finance.Quote) []interface{} {
+	ret := make([]interface{}, len(quotes))
+	for i, v := range quotes {
+		ret[i] = v
+	}
+	return ret
+}
+
 // response is a yfin quote response.
 type response struct {
 	Inner struct {
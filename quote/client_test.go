@@ -1,9 +1,23 @@
 package quote
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/fijoyapp/finance-go/form"
+	"github.com/fijoyapp/finance-go/iter"
 	tests "github.com/fijoyapp/finance-go/testing"
 	"github.com/stretchr/testify/assert"
 )
@@ -81,3 +95,239 @@ func TestGetBadQuote(t *testing.T) {
 	assert.Nil(t, q)
 	assert.Nil(t, err)
 }
+
+// setTestBackend points the package functions at a
+// test server until the test finishes.
+func setTestBackend(t *testing.T, handler http.Handler) {
+	prev := finance.GetBackend(finance.YFinBackend)
+	finance.SetBackend(finance.YFinBackend, financetest.NewServerBackend(t, handler))
+	t.Cleanup(func() { finance.SetBackend(finance.YFinBackend, prev) })
+}
+
+func TestGetContextQuote(t *testing.T) {
+	setTestBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "AAPL", r.URL.Query().Get("symbols"))
+		w.Write([]byte(`{"quoteResponse":{"result":[{"symbol":"AAPL","marketState":"REGULAR","regularMarketPrice":174.55}],"error":null}}`))
+	}))
+
+	q, err := GetContext(context.Background(), "AAPL")
+
+	assert.Nil(t, err)
+	assert.NotNil(t, q)
+	assert.Equal(t, "AAPL", q.Symbol)
+	assert.Equal(t, 174.55, q.RegularMarketPrice)
+}
+
+func TestGetContextCanceledQuote(t *testing.T) {
+	setTestBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request made with a canceled context")
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	q, err := GetContext(ctx, "AAPL")
+
+	assert.Nil(t, q)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestListRemoteError(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, "Too Many Requests")
+	}))
+	c := Client{B: backend}
+
+	iter := c.ListP(&Params{Symbols: []string{"AAPL"}})
+	assert.False(t, iter.Next())
+
+	var remoteErr *finance.RemoteError
+	assert.True(t, errors.As(iter.Err(), &remoteErr))
+	assert.Equal(t, http.StatusTooManyRequests, remoteErr.StatusCode)
+	assert.ErrorIs(t, iter.Err(), finance.ErrRateLimited)
+}
+
+func TestListBatches(t *testing.T) {
+	var batches []string
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		symbols := strings.Split(r.URL.Query().Get("symbols"), ",")
+		batches = append(batches, r.URL.Query().Get("symbols"))
+
+		// Respond out of order, like yahoo does.
+		results := []string{}
+		for i := len(symbols) - 1; i >= 0; i-- {
+			results = append(results, fmt.Sprintf(`{"symbol":%q}`, symbols[i]))
+		}
+		fmt.Fprintf(w, `{"quoteResponse":{"result":[%s]}}`, strings.Join(results, ","))
+	}))
+	c := Client{B: backend}
+
+	iter := c.ListP(&Params{Symbols: []string{"A", "B", "C", "D", "E"}, BatchSize: 2})
+
+	symbols := []string{}
+	for iter.Next() {
+		symbols = append(symbols, iter.Quote().Symbol)
+	}
+	assert.Nil(t, iter.Err())
+	assert.Equal(t, []string{"A,B", "C,D", "E"}, batches)
+	assert.Equal(t, []string{"A", "B", "C", "D", "E"}, symbols)
+}
+
+func TestMapQuotes(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"quoteResponse":{"result":[{"symbol":"MSFT"},{"symbol":"AAPL"}]}}`)
+	}))
+	c := Client{B: backend}
+
+	quotes, err := c.Map([]string{"aapl", "MSFT", "BADSYMBOL"})
+
+	assert.Len(t, quotes, 2)
+	assert.Equal(t, "AAPL", quotes["aapl"].Symbol)
+	assert.Equal(t, "MSFT", quotes["MSFT"].Symbol)
+	assert.Equal(t, &InvalidSymbolsError{Invalid: []string{"BADSYMBOL"}}, err)
+}
+
+func TestListFields(t *testing.T) {
+	var fields []string
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields = append(fields, r.URL.Query().Get("fields"))
+		fmt.Fprint(w, `{"quoteResponse":{"result":[{"symbol":"AAPL","regularMarketPrice":1.5}]}}`)
+	}))
+	c := Client{B: backend}
+
+	iter := c.ListP(&Params{
+		Symbols: []string{"AAPL"},
+		Fields:  []string{"regularMarketPrice", "regularMarketChangePercent"},
+	})
+	assert.True(t, iter.Next())
+	assert.Equal(t, 1.5, iter.Quote().RegularMarketPrice)
+
+	iter = c.ListP(&Params{Symbols: []string{"AAPL"}})
+	assert.True(t, iter.Next())
+
+	assert.Equal(t, []string{"regularMarketPrice,regularMarketChangePercent", ""}, fields)
+}
+
+func TestListExtraParams(t *testing.T) {
+	var query url.Values
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"quoteResponse":{"result":[{"symbol":"VOD.L"}]}}`)
+	}))
+	c := Client{B: backend}
+
+	extra := &form.Values{}
+	extra.Add("region", "GB")
+	extra.Add("lang", "en-GB")
+	extra.Add("symbols", "VOD.L")
+
+	iter := c.ListP(&Params{Symbols: []string{"vod.l"}, Extra: extra})
+	assert.True(t, iter.Next())
+	assert.Equal(t, url.Values{
+		"region":  {"GB"},
+		"lang":    {"en-GB"},
+		"symbols": {"VOD.L"},
+		"crumb":   {"test-crumb"},
+	}, query)
+}
+
+func TestWatchQuotes(t *testing.T) {
+	var calls int32
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		fmt.Fprintf(w, `{"quoteResponse":{"result":[{"symbol":"AAPL","regularMarketPrice":%d}]}}`, n)
+	}))
+	c := Client{B: backend}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	snapshots, err := c.Watch(ctx, []string{"AAPL"}, time.Millisecond)
+	assert.Nil(t, err)
+
+	first := <-snapshots
+	second := <-snapshots
+	assert.Equal(t, 1.0, first["AAPL"].RegularMarketPrice)
+	assert.True(t, second["AAPL"].RegularMarketPrice > 1)
+
+	cancel()
+	for range snapshots {
+	}
+}
+
+func TestWatchInvalidInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		snapshots, err := Client{}.Watch(context.Background(), []string{"AAPL"}, interval)
+		assert.Nil(t, snapshots, interval)
+		assert.ErrorContains(t, err, "watch interval must be positive", interval)
+	}
+}
+
+func TestWatchLogsFailures(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	c := Client{B: backend}
+
+	logged := make(chan string, 10)
+	finance.SetLogger(slog.New(handlerFunc(func(r slog.Record) {
+		if r.Message == "Cannot fetch watched quotes" {
+			r.Attrs(func(a slog.Attr) bool {
+				if a.Key == "symbols" {
+					logged <- a.Value.String()
+				}
+				return true
+			})
+		}
+	})))
+	defer finance.SetLogger(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	snapshots, err := c.Watch(ctx, []string{"AAPL", "MSFT"}, time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, "AAPL,MSFT", <-logged)
+
+	cancel()
+	for range snapshots {
+	}
+}
+
+// handlerFunc is a slog handler calling
+// a function with every record.
+type handlerFunc func(slog.Record)
+
+func (h handlerFunc) Enabled(context.Context, slog.Level) bool      { return true }
+func (h handlerFunc) Handle(_ context.Context, r slog.Record) error { h(r); return nil }
+func (h handlerFunc) WithAttrs([]slog.Attr) slog.Handler            { return h }
+func (h handlerFunc) WithGroup(string) slog.Handler                 { return h }
+
+// flushRecorder records the contents
+// of a buffer at every flush.
+type flushRecorder struct {
+	bytes.Buffer
+	flushes []string
+}
+
+func (f *flushRecorder) Flush() {
+	f.flushes = append(f.flushes, f.String())
+}
+
+func TestWriteJSONL(t *testing.T) {
+	i := &Iter{iter.New(nil, func(*form.Values) (interface{}, []interface{}, error) {
+		return nil, []interface{}{
+			&finance.Quote{Symbol: "AAPL"},
+			&finance.Quote{Symbol: "MSFT"},
+		}, nil
+	})}
+
+	w := &flushRecorder{}
+	assert.Nil(t, WriteJSONL(w, i))
+
+	lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Len(t, w.flushes, 2)
+	assert.Equal(t, lines[0]+"\n", w.flushes[0])
+
+	q := &finance.Quote{}
+	assert.Nil(t, json.Unmarshal([]byte(lines[1]), q))
+	assert.Equal(t, "MSFT", q.Symbol)
+}
@@ -0,0 +1,38 @@
+package quote
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// WriteJSONL writes each quote of an iterator as a JSON object on its
+// own line. If w can be flushed, like a *bufio.Writer or an
+// http.ResponseWriter, it is flushed after every quote so that
+// readers see quotes as they are written.
+func WriteJSONL(w io.Writer, iter *Iter) error {
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	for iter.Next() {
+		if err := enc.Encode(iter.Quote()); err != nil {
+			return err
+		}
+		if err := flush(w); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
+
+// flush flushes w if it buffers writes.
+func flush(w io.Writer) error {
+	switch f := w.(type) {
+	case interface{ Flush() error }:
+		return f.Flush()
+	case interface{ Flush() }:
+		f.Flush()
+	}
+	return nil
+}
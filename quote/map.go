@@ -0,0 +1,63 @@
+package quote
+
+import (
+	"fmt"
+	"strings"
+
+	finance "github.com/fijoyapp/finance-go"
+)
+
+// InvalidSymbolsError is returned by Map when
+// yahoo returned no quote for some symbols.
+type InvalidSymbolsError struct {
+	// Invalid are the requested symbols without a quote.
+	Invalid []string
+}
+
+// Error returns the invalid symbols.
+func (e *InvalidSymbolsError) Error() string {
+	return fmt.Sprintf("code: remote-error, detail: no quotes for symbols %s", strings.Join(e.Invalid, ","))
+}
+
+// Map returns quotes keyed by the requested symbols.
+func Map(symbols []string) (map[string]*finance.Quote, error) {
+	return getC().Map(symbols)
+}
+
+// Map returns quotes keyed by the requested symbols. If some symbols
+// are missing from the response, the quotes found are returned along
+// with an *InvalidSymbolsError listing the missing ones.
+func (c Client) Map(symbols []string) (map[string]*finance.Quote, error) {
+	return c.mapP(&Params{Symbols: symbols})
+}
+
+// mapP returns quotes keyed by the symbols requested in params.
+func (c Client) mapP(params *Params) (map[string]*finance.Quote, error) {
+	i := c.ListP(params)
+	symbols := params.Symbols
+
+	// Yahoo may change the case of requested symbols.
+	found := map[string]*finance.Quote{}
+	for i.Next() {
+		q := i.Quote()
+		found[strings.ToUpper(q.Symbol)] = q
+	}
+	if err := i.Err(); err != nil {
+		return nil, err
+	}
+
+	quotes := make(map[string]*finance.Quote, len(symbols))
+	invalid := []string{}
+	for _, s := range symbols {
+		if q, ok := found[strings.ToUpper(s)]; ok {
+			quotes[s] = q
+		} else {
+			invalid = append(invalid, s)
+		}
+	}
+
+	if len(invalid) > 0 {
+		return quotes, &InvalidSymbolsError{Invalid: invalid}
+	}
+	return quotes, nil
+}
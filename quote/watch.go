@@ -0,0 +1,63 @@
+package quote
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	finance "github.com/fijoyapp/finance-go"
+)
+
+// Watch polls quotes for symbols every interval
+// and pushes snapshots keyed by symbol.
+func Watch(ctx context.Context, symbols []string, interval time.Duration) (<-chan map[string]*finance.Quote, error) {
+	return getC().Watch(ctx, symbols, interval)
+}
+
+// Watch polls quotes for symbols every interval and pushes snapshots
+// keyed by symbol, starting immediately. Ticks that elapse while a
+// fetch is in flight or a snapshot is unread are skipped. Failed
+// fetches are logged and skipped too. The channel is closed once ctx
+// is done. An argument error is returned if interval isn't positive.
+func (c Client) Watch(ctx context.Context, symbols []string, interval time.Duration) (<-chan map[string]*finance.Quote, error) {
+	if interval <= 0 {
+		return nil, finance.CreateArgumentErrorS("watch interval must be positive")
+	}
+
+	snapshots := make(chan map[string]*finance.Quote)
+
+	go func() {
+		defer close(snapshots)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			p := &Params{Symbols: symbols}
+			p.Context = &ctx
+			quotes, err := c.mapP(p)
+
+			var invalid *InvalidSymbolsError
+			if err != nil && !errors.As(err, &invalid) {
+				finance.LogEvent(ctx, slog.LevelError, "Cannot fetch watched quotes",
+					"symbols", strings.Join(symbols, ","), "error", err)
+			} else {
+				select {
+				case snapshots <- quotes:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return snapshots, nil
+}
@@ -0,0 +1,199 @@
+package quotesummary
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	finance "github.com/fijoyapp/finance-go"
+	form "github.com/fijoyapp/finance-go/form"
+)
+
+// Modules that can be requested from the quoteSummary api.
+const (
+	// ModuleAssetProfile is the company profile module.
+	ModuleAssetProfile = "assetProfile"
+	// ModuleSummaryDetail is the trading summary module.
+	ModuleSummaryDetail = "summaryDetail"
+	// ModuleFinancialData is the financial data module.
+	ModuleFinancialData = "financialData"
+	// ModuleQuoteType is the symbol classification module.
+	ModuleQuoteType = "quoteType"
+	// ModuleIncomeStatementHistory is the annual income statements module.
+	ModuleIncomeStatementHistory = "incomeStatementHistory"
+	// ModuleIncomeStatementHistoryQuarterly is the quarterly income statements module.
+	ModuleIncomeStatementHistoryQuarterly = "incomeStatementHistoryQuarterly"
+	// ModuleBalanceSheetHistory is the annual balance sheets module.
+	ModuleBalanceSheetHistory = "balanceSheetHistory"
+	// ModuleBalanceSheetHistoryQuarterly is the quarterly balance sheets module.
+	ModuleBalanceSheetHistoryQuarterly = "balanceSheetHistoryQuarterly"
+	// ModuleCashflowStatementHistory is the annual cash flow statements module.
+	ModuleCashflowStatementHistory = "cashflowStatementHistory"
+	// ModuleCashflowStatementHistoryQuarterly is the quarterly cash flow statements module.
+	ModuleCashflowStatementHistoryQuarterly = "cashflowStatementHistoryQuarterly"
+	// ModuleCalendarEvents is the upcoming events module.
+	ModuleCalendarEvents = "calendarEvents"
+	// ModuleEarnings is the earnings history module.
+	ModuleEarnings = "earnings"
+	// ModuleRecommendationTrend is the analyst recommendations module.
+	ModuleRecommendationTrend = "recommendationTrend"
+	// ModuleUpgradeDowngradeHistory is the analyst rating changes module.
+	ModuleUpgradeDowngradeHistory = "upgradeDowngradeHistory"
+	// ModuleESGScores is the sustainability scores module.
+	ModuleESGScores = "esgScores"
+	// ModuleInsiderTransactions is the insider transactions module.
+	ModuleInsiderTransactions = "insiderTransactions"
+	// ModuleInstitutionOwnership is the institutional holders module.
+	ModuleInstitutionOwnership = "institutionOwnership"
+	// ModuleMajorHoldersBreakdown is the ownership breakdown module.
+	ModuleMajorHoldersBreakdown = "majorHoldersBreakdown"
+	// ModuleDefaultKeyStatistics is the key statistics module.
+	ModuleDefaultKeyStatistics = "defaultKeyStatistics"
+	// ModuleFundProfile is the fund profile and fees module.
+	ModuleFundProfile = "fundProfile"
+	// ModuleTopHoldings is the fund holdings module.
+	ModuleTopHoldings = "topHoldings"
+	// ModuleFundPerformance is the fund returns module.
+	ModuleFundPerformance = "fundPerformance"
+	// ModuleFuturesChain is the futures contract months module.
+	ModuleFuturesChain = "futuresChain"
+	// ModuleComponents is the index constituents module.
+	ModuleComponents = "components"
+	// ModuleSECFilings is the sec filings module.
+	ModuleSECFilings = "secFilings"
+)
+
+// Client is used to invoke quoteSummary APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and quote summary information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Symbol  string   `form:"-"`
+	Modules []string `form:"-"`
+
+	// Internal request fields.
+	modules string `form:"modules"`
+}
+
+// Get returns the requested quote summary modules for a symbol.
+func Get(symbol string, modules []string) (*finance.QuoteSummary, error) {
+	return GetP(&Params{Symbol: symbol, Modules: modules})
+}
+
+// GetP returns a quote summary and requires a params
+// struct as an argument.
+func GetP(params *Params) (*finance.QuoteSummary, error) {
+	return getC().GetP(params)
+}
+
+// GetP returns a quote summary.
+func (c Client) GetP(params *Params) (*finance.QuoteSummary, error) {
+
+	// Validate input.
+	// TODO: validate symbol..
+	if params == nil || len(params.Symbol) == 0 || len(params.Modules) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	if params.Context == nil {
+		ctx := context.TODO()
+		params.Context = &ctx
+	}
+
+	params.modules = strings.Join(params.Modules, ",")
+
+	body := &form.Values{}
+	form.AppendTo(body, params)
+
+	resp := response{}
+	err := c.B.Call("v10/finance/quoteSummary/"+params.Symbol, body, params.Context, &resp)
+	if err != nil {
+		// Requests for unknown modules or symbols are rejected
+		// with an error status, but still carry a yfin error.
+		if remoteErr, ok := err.(*finance.RemoteError); ok {
+			if json.Unmarshal([]byte(remoteErr.Body), &resp) == nil && resp.Inner.Error != nil {
+				return nil, finance.CreateRemoteError(resp.Inner.Error)
+			}
+		}
+		return nil, finance.CreateRemoteError(err)
+	}
+
+	if resp.Inner.Error != nil {
+		return nil, finance.CreateRemoteError(resp.Inner.Error)
+	}
+
+	if len(resp.Inner.Results) == 0 {
+		return nil, finance.CreateRemoteErrorS("no results in quote summary response")
+	}
+
+	summary := &finance.QuoteSummary{}
+	if err := unmarshalModules(resp.Inner.Results[0], summary); err != nil {
+		return nil, finance.CreateRemoteError(err)
+	}
+	summary.Symbol = params.Symbol
+
+	return summary, nil
+}
+
+// unmarshalModules decodes a quote summary result into v. Yahoo wraps
+// numeric module values as objects like {"raw": 1.5, "fmt": "1.50"} and
+// sends an empty object for values that aren't reported, so the result
+// is flattened to bare values and nulls before it is decoded.
+func unmarshalModules(data json.RawMessage, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var raw interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+
+	flat, err := json.Marshal(flatten(raw))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(flat, v)
+}
+
+// flatten replaces formatted value objects with their raw values
+// and empty objects with nil, recursively.
+func flatten(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if len(t) == 0 {
+			return nil
+		}
+		if raw, ok := t["raw"]; ok {
+			return raw
+		}
+		for k, e := range t {
+			t[k] = flatten(e)
+		}
+		return t
+	case []interface{}:
+		for i, e := range t {
+			t[i] = flatten(e)
+		}
+		return t
+	}
+	return v
+}
+
+// response is a yfin quote summary response.
+type response struct {
+	Inner struct {
+		Results []json.RawMessage  `json:"result"`
+		Error   *finance.YfinError `json:"error"`
+	} `json:"quoteSummary"`
+}
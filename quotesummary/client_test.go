@@ -0,0 +1,102 @@
+package quotesummary
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetQuoteSummary(t *testing.T) {
+	var path string
+	var query url.Values
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"quoteSummary":{"result":[{
+			"summaryDetail":{"previousClose":{"raw":187.5,"fmt":"187.50"},"currency":"USD"},
+			"financialData":{"currentPrice":{"raw":188.25,"fmt":"188.25"},"recommendationKey":"buy"}
+		}],"error":null}}`)
+	}))
+	c := Client{B: backend}
+
+	s, err := c.GetP(&Params{Symbol: "AAPL", Modules: []string{ModuleSummaryDetail, ModuleFinancialData}})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, s)
+	assert.Equal(t, "/v10/finance/quoteSummary/AAPL", path)
+	assert.Equal(t, "summaryDetail,financialData", query.Get("modules"))
+	assert.Equal(t, "AAPL", s.Symbol)
+	assert.Equal(t, 187.5, s.SummaryDetail.PreviousClose)
+	assert.Equal(t, 188.25, s.FinancialData.CurrentPrice)
+	assert.Nil(t, s.AssetProfile)
+}
+
+func TestGetQuoteSummaryNoModules(t *testing.T) {
+	s, err := Get("AAPL", nil)
+
+	assert.Nil(t, s)
+	assert.Equal(t, "code: api-error, detail: missing function argument", err.Error())
+}
+
+func TestUnmarshalModules(t *testing.T) {
+	data := json.RawMessage(`{
+		"summaryDetail": {
+			"previousClose": {"raw": 187.5, "fmt": "187.50"},
+			"marketCap": {"raw": 954621542400, "fmt": "954.62B", "longFmt": "954,621,542,400"},
+			"dividendRate": {},
+			"currency": "USD"
+		}
+	}`)
+
+	s := &finance.QuoteSummary{}
+	assert.Nil(t, unmarshalModules(data, s))
+	assert.NotNil(t, s.SummaryDetail)
+	assert.Equal(t, 187.5, s.SummaryDetail.PreviousClose)
+	assert.Equal(t, int64(954621542400), s.SummaryDetail.MarketCap)
+	assert.Equal(t, 0.0, s.SummaryDetail.DividendRate)
+	assert.Equal(t, "USD", s.SummaryDetail.Currency)
+}
+
+func TestUnmarshalModulesNotReported(t *testing.T) {
+	data := json.RawMessage(`{
+		"defaultKeyStatistics": {
+			"beta": {"raw": 1.2, "fmt": "1.20"},
+			"pegRatio": {},
+			"lastSplitFactor": null
+		}
+	}`)
+
+	s := &finance.QuoteSummary{}
+	assert.Nil(t, unmarshalModules(data, s))
+	assert.NotNil(t, s.DefaultKeyStatistics)
+	assert.Equal(t, 1.2, *s.DefaultKeyStatistics.Beta)
+	assert.Nil(t, s.DefaultKeyStatistics.PegRatio)
+	assert.Nil(t, s.DefaultKeyStatistics.LastSplitFactor)
+	assert.Nil(t, s.DefaultKeyStatistics.ForwardPE)
+}
+
+func TestUnmarshalModulesFund(t *testing.T) {
+	data := json.RawMessage(`{
+		"topHoldings": {
+			"holdings": [{"symbol": "AAPL", "holdingName": "Apple Inc", "holdingPercent": {"raw": 0.07, "fmt": "7.00%"}}],
+			"sectorWeightings": [{"technology": {"raw": 0.3, "fmt": "30.00%"}}]
+		},
+		"fundPerformance": {
+			"annualTotalReturns": {"returns": [{"year": "2023", "annualValue": {"raw": 0.12}}, {"year": "2022", "annualValue": {}}]}
+		}
+	}`)
+
+	s := &finance.QuoteSummary{}
+	assert.Nil(t, unmarshalModules(data, s))
+	assert.Equal(t, 0.07, s.TopHoldings.Holdings[0].Percent)
+	assert.Equal(t, 0.3, s.TopHoldings.SectorWeightings[0]["technology"])
+	assert.Equal(t, 2023, s.FundPerformance.AnnualTotalReturns.Returns[0].Year)
+	assert.Equal(t, 0.12, *s.FundPerformance.AnnualTotalReturns.Returns[0].Return)
+	assert.Nil(t, s.FundPerformance.AnnualTotalReturns.Returns[1].Return)
+}
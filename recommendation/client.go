@@ -0,0 +1,157 @@
+package recommendation
+
+import (
+	"sort"
+	"time"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/datetime"
+	"github.com/fijoyapp/finance-go/quotesummary"
+)
+
+// Client is used to invoke analyst recommendation APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and symbol information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Symbol string `form:"-"`
+}
+
+// GetTrend returns the analyst recommendation trend for a symbol.
+func GetTrend(symbol string) (*finance.RecommendationTrend, error) {
+	return GetTrendP(&Params{Symbol: symbol})
+}
+
+// GetTrendP returns a recommendation trend and requires
+// a params struct as an argument.
+func GetTrendP(params *Params) (*finance.RecommendationTrend, error) {
+	return getC().GetTrendP(params)
+}
+
+// GetTrendP returns a recommendation trend.
+func (c Client) GetTrendP(params *Params) (*finance.RecommendationTrend, error) {
+	summary, err := c.summary(params, quotesummary.ModuleRecommendationTrend)
+	if err != nil {
+		return nil, err
+	}
+
+	trend := summary.RecommendationTrend
+	if trend == nil {
+		trend = &finance.RecommendationTrend{}
+	}
+	trend.Symbol = params.Symbol
+
+	return trend, nil
+}
+
+// GetPriceTarget returns the analyst price target for a symbol.
+// Symbols without analyst coverage return a zeroed target
+// with HasCoverage unset rather than an error.
+func GetPriceTarget(symbol string) (*finance.PriceTarget, error) {
+	return GetPriceTargetP(&Params{Symbol: symbol})
+}
+
+// GetPriceTargetP returns a price target and requires
+// a params struct as an argument.
+func GetPriceTargetP(params *Params) (*finance.PriceTarget, error) {
+	return getC().GetPriceTargetP(params)
+}
+
+// GetPriceTargetP returns a price target.
+func (c Client) GetPriceTargetP(params *Params) (*finance.PriceTarget, error) {
+	summary, err := c.summary(params, quotesummary.ModuleFinancialData)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &finance.PriceTarget{Symbol: params.Symbol}
+
+	data := summary.FinancialData
+	if data == nil || data.NumberOfAnalystOpinions == 0 {
+		return target, nil
+	}
+
+	target.TargetHigh = data.TargetHighPrice
+	target.TargetLow = data.TargetLowPrice
+	target.TargetMean = data.TargetMeanPrice
+	target.TargetMedian = data.TargetMedianPrice
+	target.NumberOfAnalystOpinions = data.NumberOfAnalystOpinions
+	target.HasCoverage = true
+
+	return target, nil
+}
+
+// GetUpgradeHistory returns the analyst rating changes
+// for a symbol, newest first.
+func GetUpgradeHistory(symbol string) ([]finance.RatingAction, error) {
+	return GetUpgradeHistoryP(&Params{Symbol: symbol})
+}
+
+// GetUpgradeHistoryP returns analyst rating changes and requires
+// a params struct as an argument.
+func GetUpgradeHistoryP(params *Params) ([]finance.RatingAction, error) {
+	return getC().GetUpgradeHistoryP(params)
+}
+
+// GetUpgradeHistoryP returns analyst rating changes.
+func (c Client) GetUpgradeHistoryP(params *Params) ([]finance.RatingAction, error) {
+	summary, err := c.summary(params, quotesummary.ModuleUpgradeDowngradeHistory, quotesummary.ModuleQuoteType)
+	if err != nil {
+		return nil, err
+	}
+
+	if summary.UpgradeDowngradeHistory == nil {
+		return nil, nil
+	}
+
+	// Grade dates are reported as epochs, so localize
+	// them to the exchange timezone when it is known.
+	loc := time.UTC
+	if summary.QuoteType != nil {
+		if l, err := time.LoadLocation(summary.QuoteType.TimeZoneFullName); err == nil {
+			loc = l
+		}
+	}
+
+	history := summary.UpgradeDowngradeHistory.History
+	actions := make([]finance.RatingAction, len(history))
+	for i, h := range history {
+		t := time.Unix(int64(h.EpochGradeDate), 0).In(loc)
+		actions[i] = finance.RatingAction{
+			Firm:      h.Firm,
+			FromGrade: h.FromGrade,
+			ToGrade:   h.ToGrade,
+			Action:    h.Action,
+			GradeDate: *datetime.New(&t),
+		}
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].GradeDate.Unix() > actions[j].GradeDate.Unix()
+	})
+
+	return actions, nil
+}
+
+// summary fetches the given quote summary modules for the params symbol.
+func (c Client) summary(params *Params, modules ...string) (*finance.QuoteSummary, error) {
+	if params == nil || len(params.Symbol) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	return quotesummary.Client{B: c.B}.GetP(&quotesummary.Params{
+		Params:  params.Params,
+		Symbol:  params.Symbol,
+		Modules: modules,
+	})
+}
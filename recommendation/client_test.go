@@ -0,0 +1,93 @@
+package recommendation
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient returns a client for a server
+// responding with the given quote summary result.
+func newTestClient(t *testing.T, result string) Client {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"quoteSummary":{"result":[%s]}}`, result)
+	}))
+	return Client{B: backend}
+}
+
+func TestGetTrend(t *testing.T) {
+	c := newTestClient(t, `{"recommendationTrend":{"trend":[
+		{"period":"0m","strongBuy":11,"buy":21,"hold":6,"sell":0,"strongSell":0},
+		{"period":"-1m","strongBuy":10,"buy":20,"hold":7,"sell":1,"strongSell":0}
+	]}}`)
+
+	trend, err := c.GetTrendP(&Params{Symbol: "AAPL"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, trend)
+	assert.Equal(t, "AAPL", trend.Symbol)
+	assert.Len(t, trend.Trend, 2)
+	assert.Equal(t, "0m", trend.Trend[0].Period)
+	assert.Equal(t, 11, trend.Trend[0].StrongBuy)
+	assert.Equal(t, 1, trend.Trend[1].Sell)
+}
+
+func TestNilParamsTrend(t *testing.T) {
+	trend, err := GetTrendP(nil)
+
+	assert.Nil(t, trend)
+	assert.Equal(t, "code: api-error, detail: missing function argument", err.Error())
+}
+
+func TestGetPriceTarget(t *testing.T) {
+	c := newTestClient(t, `{"financialData":{
+		"targetHighPrice":250,"targetLowPrice":160,"targetMeanPrice":205.5,
+		"targetMedianPrice":210,"numberOfAnalystOpinions":38
+	}}`)
+
+	target, err := c.GetPriceTargetP(&Params{Symbol: "AAPL"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, target)
+	assert.Equal(t, "AAPL", target.Symbol)
+	assert.True(t, target.HasCoverage)
+	assert.Equal(t, 250.0, target.TargetHigh)
+	assert.Equal(t, 160.0, target.TargetLow)
+	assert.Equal(t, 205.5, target.TargetMean)
+	assert.Equal(t, 38, target.NumberOfAnalystOpinions)
+}
+
+func TestGetPriceTargetNoCoverage(t *testing.T) {
+	c := newTestClient(t, `{"financialData":{"numberOfAnalystOpinions":0}}`)
+
+	target, err := c.GetPriceTargetP(&Params{Symbol: "^GSPC"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, target)
+	assert.False(t, target.HasCoverage)
+	assert.Equal(t, 0.0, target.TargetMean)
+}
+
+func TestGetUpgradeHistory(t *testing.T) {
+	c := newTestClient(t, `{
+		"quoteType":{"symbol":"AAPL","timeZoneFullName":"America/New_York"},
+		"upgradeDowngradeHistory":{"history":[
+			{"epochGradeDate":1700000000,"firm":"Morgan Stanley","toGrade":"Overweight","fromGrade":"Overweight","action":"main"},
+			{"epochGradeDate":1710000000,"firm":"Goldman Sachs","toGrade":"Buy","fromGrade":"Neutral","action":"up"}
+		]}
+	}`)
+
+	actions, err := c.GetUpgradeHistoryP(&Params{Symbol: "AAPL"})
+
+	assert.Nil(t, err)
+	assert.Len(t, actions, 2)
+	assert.Equal(t, "Goldman Sachs", actions[0].Firm)
+	assert.Equal(t, finance.RatingActionUpgrade, actions[0].Action)
+	assert.Equal(t, 1710000000, actions[0].GradeDate.Unix())
+	assert.Equal(t, "America/New_York", actions[0].GradeDate.Time().Location().String())
+	assert.Equal(t, finance.RatingActionMaintain, actions[1].Action)
+}
@@ -0,0 +1,179 @@
+package screener
+
+import (
+	"context"
+	"encoding/json"
+
+	finance "github.com/fijoyapp/finance-go"
+	form "github.com/fijoyapp/finance-go/form"
+	"github.com/fijoyapp/finance-go/iter"
+)
+
+// Predefined screens offered by yahoo.
+const (
+	// DayGainers are the biggest gainers of the day.
+	DayGainers = "day_gainers"
+	// DayLosers are the biggest losers of the day.
+	DayLosers = "day_losers"
+	// MostActives are the most traded equities of the day.
+	MostActives = "most_actives"
+	// MostShortedStocks are the equities with the highest short interest.
+	MostShortedStocks = "most_shorted_stocks"
+	// UndervaluedGrowthStocks are growth equities with low earnings ratios.
+	UndervaluedGrowthStocks = "undervalued_growth_stocks"
+	// GrowthTechnologyStocks are technology equities with high growth.
+	GrowthTechnologyStocks = "growth_technology_stocks"
+)
+
+// Client is used to invoke screener APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and screen information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Name string `form:"scrIds"`
+	// Count is the number of quotes returned, or the page
+	// size when iterating. Yahoo returns 25 if unset.
+	Count int `form:"count"`
+	// Offset is the number of quotes skipped, for paging.
+	Offset int `form:"offset"`
+
+	// Internal request fields.
+	formatted string `form:"formatted"`
+}
+
+// Iter is an iterator for a list of screened quotes.
+// The embedded Iter carries methods with it;
+// see its documentation for details.
+type Iter struct {
+	*iter.Iter
+}
+
+// Quote returns the most recent Quote
+// visited by a call to Next.
+func (i *Iter) Quote() *finance.Quote {
+	return i.Current().(*finance.Quote)
+}
+
+// GetPredefined returns up to count quotes matching
+// a predefined screen, such as DayGainers.
+func GetPredefined(name string, count int) ([]finance.Quote, error) {
+	return GetPredefinedP(&Params{Name: name, Count: count})
+}
+
+// GetPredefinedP returns screened quotes and requires a params
+// struct as an argument.
+func GetPredefinedP(params *Params) ([]finance.Quote, error) {
+	return getC().GetPredefinedP(params)
+}
+
+// GetPredefinedP returns screened quotes.
+func (c Client) GetPredefinedP(params *Params) ([]finance.Quote, error) {
+	result, err := c.page(params)
+	if err != nil {
+		return nil, err
+	}
+	return result.Quotes, nil
+}
+
+// ListPredefined returns an iterator over every quote
+// matching a predefined screen, such as MostActives.
+func ListPredefined(name string) *Iter {
+	return ListPredefinedP(&Params{Name: name})
+}
+
+// ListPredefinedP returns a screened quote iterator and
+// requires a params struct as an argument.
+func ListPredefinedP(params *Params) *Iter {
+	return getC().ListPredefinedP(params)
+}
+
+// ListPredefinedP returns a screened quote iterator. Pages of
+// Count quotes are fetched, starting at Offset, as it advances.
+func (c Client) ListPredefinedP(params *Params) *Iter {
+	if params == nil {
+		return &Iter{iter.NewE(finance.CreateArgumentError())}
+	}
+
+	return &Iter{iter.NewPaged(func(offset int) (interface{}, []interface{}, int, error) {
+		p := *params
+		p.Offset = params.Offset + offset
+
+		result, err := c.page(&p)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		values := make([]interface{}, len(result.Quotes))
+		for i := range result.Quotes {
+			values[i] = &result.Quotes[i]
+		}
+		return nil, values, result.Total - params.Offset, nil
+	})}
+}
+
+// page requests a single page of screened quotes.
+func (c Client) page(params *Params) (*result, error) {
+
+	if params == nil || len(params.Name) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	if params.Context == nil {
+		ctx := context.TODO()
+		params.Context = &ctx
+	}
+
+	// Request raw values rather than
+	// {"raw": 1.5, "fmt": "1.50"} objects.
+	params.formatted = "false"
+
+	body := &form.Values{}
+	form.AppendTo(body, params)
+
+	resp := response{}
+	err := c.B.Call("v1/finance/screener/predefined/saved", body, params.Context, &resp)
+	if err != nil {
+		// Unknown screens are rejected with an
+		// error status, but still carry a yfin error.
+		if remoteErr, ok := err.(*finance.RemoteError); ok {
+			if json.Unmarshal([]byte(remoteErr.Body), &resp) == nil && resp.Inner.Error != nil {
+				return nil, finance.CreateRemoteError(resp.Inner.Error)
+			}
+		}
+		return nil, finance.CreateRemoteError(err)
+	}
+
+	if resp.Inner.Error != nil {
+		return nil, finance.CreateRemoteError(resp.Inner.Error)
+	}
+
+	if len(resp.Inner.Result) == 0 {
+		return nil, finance.CreateRemoteErrorS("no results in screener response")
+	}
+
+	return &resp.Inner.Result[0], nil
+}
+
+// response is a yfin screener response.
+type response struct {
+	Inner struct {
+		Result []result           `json:"result"`
+		Error  *finance.YfinError `json:"error"`
+	} `json:"finance"`
+}
+
+// result is a page of screened quotes.
+type result struct {
+	Total  int             `json:"total"`
+	Quotes []finance.Quote `json:"quotes"`
+}
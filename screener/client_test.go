@@ -0,0 +1,93 @@
+package screener
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPredefined(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/finance/screener/predefined/saved", r.URL.Path)
+		fmt.Fprint(w, `{"finance":{"result":[{"id":"day_gainers","quotes":[
+			{"symbol":"AAA","regularMarketChangePercent":12.5}
+		]}],"error":null}}`)
+	}))
+	c := Client{B: backend}
+
+	quotes, err := c.GetPredefinedP(&Params{Name: DayGainers, Count: 10})
+
+	assert.Nil(t, err)
+	assert.Len(t, quotes, 1)
+	assert.Equal(t, "AAA", quotes[0].Symbol)
+	assert.Equal(t, 12.5, quotes[0].RegularMarketChangePercent)
+}
+
+func TestGetPredefinedNoName(t *testing.T) {
+	quotes, err := GetPredefined("", 10)
+
+	assert.Nil(t, quotes)
+	assert.Equal(t, "code: api-error, detail: missing function argument", err.Error())
+}
+
+func TestGetPredefinedPaging(t *testing.T) {
+	var query url.Values
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"finance":{"result":[{"id":"day_gainers","total":100,"start":25,"count":2,"quotes":[{"symbol":"AAA"},{"symbol":"BBB"}]}],"error":null}}`)
+	}))
+	c := Client{B: backend}
+
+	quotes, err := c.GetPredefinedP(&Params{Name: DayGainers, Count: 2, Offset: 25})
+	assert.Nil(t, err)
+	assert.Len(t, quotes, 2)
+	assert.Equal(t, "BBB", quotes[1].Symbol)
+	assert.Equal(t, "day_gainers", query.Get("scrIds"))
+	assert.Equal(t, "2", query.Get("count"))
+	assert.Equal(t, "25", query.Get("offset"))
+	assert.Equal(t, "false", query.Get("formatted"))
+}
+
+func TestGetPredefinedUnknown(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"finance":{"result":null,"error":{"code":"Bad Request","description":"Invalid scrIds"}}}`)
+	}))
+	c := Client{B: backend}
+
+	quotes, err := c.GetPredefinedP(&Params{Name: "bogus"})
+	assert.Nil(t, quotes)
+	assert.EqualError(t, err, `code: remote-error, detail: {"code":"Bad Request","description":"Invalid scrIds"}`)
+}
+
+func TestListPredefined(t *testing.T) {
+	var offsets []string
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset := r.URL.Query().Get("offset")
+		offsets = append(offsets, offset)
+		switch offset {
+		case "":
+			fmt.Fprint(w, `{"finance":{"result":[{"total":5,"quotes":[{"symbol":"A"},{"symbol":"B"}]}]}}`)
+		case "2":
+			fmt.Fprint(w, `{"finance":{"result":[{"total":5,"quotes":[{"symbol":"C"},{"symbol":"D"}]}]}}`)
+		default:
+			fmt.Fprint(w, `{"finance":{"result":[{"total":5,"quotes":[{"symbol":"E"}]}]}}`)
+		}
+	}))
+	c := Client{B: backend}
+
+	iter := c.ListPredefinedP(&Params{Name: MostActives, Count: 2})
+	assert.Equal(t, 5, iter.Count())
+
+	symbols := []string{}
+	for iter.Next() {
+		symbols = append(symbols, iter.Quote().Symbol)
+	}
+	assert.Nil(t, iter.Err())
+	assert.Equal(t, []string{"A", "B", "C", "D", "E"}, symbols)
+	assert.Equal(t, []string{"", "2", "4"}, offsets)
+}
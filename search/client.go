@@ -0,0 +1,67 @@
+package search
+
+import (
+	"context"
+
+	finance "github.com/fijoyapp/finance-go"
+	form "github.com/fijoyapp/finance-go/form"
+)
+
+// Client is used to invoke search APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and search information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Query string `form:"q"`
+	// QuotesCount and NewsCount limit the number of quote
+	// and news matches. Yahoo's defaults apply if unset.
+	QuotesCount int `form:"quotesCount"`
+	NewsCount   int `form:"newsCount"`
+}
+
+// Search returns the quotes and news matching a query,
+// such as a company name or partial ticker.
+func Search(query string) (*finance.SearchResult, error) {
+	return SearchP(&Params{Query: query})
+}
+
+// SearchP returns search results and requires a params
+// struct as an argument.
+func SearchP(params *Params) (*finance.SearchResult, error) {
+	return getC().SearchP(params)
+}
+
+// SearchP returns search results.
+func (c Client) SearchP(params *Params) (*finance.SearchResult, error) {
+
+	if params == nil || len(params.Query) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	if params.Context == nil {
+		ctx := context.TODO()
+		params.Context = &ctx
+	}
+
+	body := &form.Values{}
+	form.AppendTo(body, params)
+	finance.AppendLocale(body)
+
+	result := &finance.SearchResult{}
+	err := c.B.Call("v1/finance/search", body, params.Context, result)
+	if err != nil {
+		return nil, finance.CreateRemoteError(err)
+	}
+
+	return result, nil
+}
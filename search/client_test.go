@@ -0,0 +1,57 @@
+package search
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch(t *testing.T) {
+	var query url.Values
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/finance/search", r.URL.Path)
+		query = r.URL.Query()
+		w.Write([]byte(`{"count":2,"quotes":[
+			{"symbol":"AAPL","shortname":"Apple Inc.","exchange":"NMS","quoteType":"EQUITY"},
+			{"symbol":"APLE","shortname":"Apple Hospitality REIT, Inc.","exchange":"NYQ","quoteType":"EQUITY"}
+		],"news":[{"uuid":"abc","title":"Apple news","publisher":"Reuters","relatedTickers":["AAPL"]}]}`))
+	}))
+	c := Client{B: backend}
+
+	result, err := c.SearchP(&Params{Query: "Apple"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "Apple", query.Get("q"))
+	assert.Len(t, result.Quotes, 2)
+	assert.Equal(t, "AAPL", result.Quotes[0].Symbol)
+	assert.Len(t, result.News, 1)
+	assert.Equal(t, []string{"AAPL"}, result.News[0].RelatedTickers)
+}
+
+func TestSearchLimits(t *testing.T) {
+	var query url.Values
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`{"count":1,"quotes":[{"symbol":"AAPL"}],"news":[]}`))
+	}))
+	c := Client{B: backend}
+
+	result, err := c.SearchP(&Params{Query: "Apple", QuotesCount: 1, NewsCount: 1})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1", query.Get("quotesCount"))
+	assert.Equal(t, "1", query.Get("newsCount"))
+	assert.Len(t, result.Quotes, 1)
+	assert.Empty(t, result.News)
+}
+
+func TestEmptySearch(t *testing.T) {
+	result, err := Search("")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "code: api-error, detail: missing function argument", err.Error())
+}
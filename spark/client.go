@@ -0,0 +1,169 @@
+package spark
+
+import (
+	"context"
+	"strings"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/datetime"
+	form "github.com/fijoyapp/finance-go/form"
+)
+
+// BatchSize is the maximum number of symbols
+// yahoo accepts in a single spark request.
+const BatchSize = 20
+
+// Client is used to invoke spark APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and spark information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Symbols []string `form:"-"`
+	// Range is a shorthand such as "1d" or "1mo". Defaults to "1d".
+	Range string `form:"range"`
+	// Interval is the spacing of the points. Defaults to "5m".
+	Interval datetime.Interval `form:"interval"`
+
+	// Internal request fields.
+	sym string `form:"symbols"`
+}
+
+// Get returns sparks for symbols keyed by symbol.
+func Get(symbols []string, rangeStr, interval string) (map[string]*finance.Spark, error) {
+	return GetP(&Params{Symbols: symbols, Range: rangeStr, Interval: datetime.Interval(interval)})
+}
+
+// GetP returns sparks and requires a params struct as an argument.
+func GetP(params *Params) (map[string]*finance.Spark, error) {
+	return getC().GetP(params)
+}
+
+// GetP returns sparks keyed by symbol, requesting them in batches of
+// BatchSize. Symbols without a spark are left out of the map and
+// reported together in a finance.ListError, without failing the others.
+func (c Client) GetP(params *Params) (map[string]*finance.Spark, error) {
+	if params == nil || len(params.Symbols) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	if params.Context == nil {
+		ctx := context.TODO()
+		params.Context = &ctx
+	}
+	if params.Range == "" {
+		params.Range = string(datetime.OneDay)
+	}
+	if params.Interval == "" {
+		params.Interval = datetime.FiveMins
+	}
+
+	sparks := map[string]*finance.Spark{}
+	failed := finance.ListError{}
+	for start := 0; start < len(params.Symbols); start += BatchSize {
+		end := start + BatchSize
+		if end > len(params.Symbols) {
+			end = len(params.Symbols)
+		}
+		batch := params.Symbols[start:end]
+
+		results, err := c.get(params, batch)
+		if err != nil {
+			for _, s := range batch {
+				failed[s] = err
+			}
+			continue
+		}
+
+		for _, s := range batch {
+			if spark, ok := results[strings.ToUpper(s)]; ok {
+				sparks[s] = spark
+			} else {
+				failed[s] = finance.CreateRemoteErrorS("no spark in response")
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return sparks, failed
+	}
+	return sparks, nil
+}
+
+// get requests sparks for a single batch of symbols,
+// keyed by the upper case symbol.
+func (c Client) get(params *Params, symbols []string) (map[string]*finance.Spark, error) {
+	params.sym = strings.Join(symbols, ",")
+
+	body := &form.Values{}
+	form.AppendTo(body, params)
+
+	resp := response{}
+	err := c.B.Call("v8/finance/spark", body, params.Context, &resp)
+	if err != nil {
+		return nil, finance.CreateRemoteError(err)
+	}
+	if resp.Inner.Error != nil {
+		return nil, finance.CreateRemoteError(resp.Inner.Error)
+	}
+
+	sparks := map[string]*finance.Spark{}
+	for _, r := range resp.Inner.Results {
+		if r == nil || len(r.Response) == 0 || r.Response[0] == nil {
+			continue
+		}
+		sr := r.Response[0]
+
+		spark := &finance.Spark{
+			Symbol:        r.Symbol,
+			PreviousClose: sr.Meta.ChartPreviousClose,
+			Timestamps:    []int{},
+			Close:         []float64{},
+		}
+		if len(sr.Indicators.Quote) > 0 && sr.Indicators.Quote[0] != nil {
+			closes := sr.Indicators.Quote[0].Close
+			for i, t := range sr.Timestamp {
+				// Points without a close are gaps.
+				if i >= len(closes) || closes[i] == nil {
+					continue
+				}
+				spark.Timestamps = append(spark.Timestamps, t)
+				spark.Close = append(spark.Close, *closes[i])
+			}
+		}
+		sparks[strings.ToUpper(r.Symbol)] = spark
+	}
+
+	return sparks, nil
+}
+
+// response is a yfin spark response.
+type response struct {
+	Inner struct {
+		Results []*struct {
+			Symbol   string    `json:"symbol"`
+			Response []*result `json:"response"`
+		} `json:"result"`
+		Error *finance.YfinError `json:"error"`
+	} `json:"spark"`
+}
+
+// result is a single spark series.
+type result struct {
+	Meta       finance.ChartMeta `json:"meta"`
+	Timestamp  []int             `json:"timestamp"`
+	Indicators struct {
+		Quote []*struct {
+			Close []*float64 `json:"close"`
+		} `json:"quote"`
+	} `json:"indicators"`
+}
@@ -0,0 +1,77 @@
+package spark
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSpark(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v8/finance/spark", r.URL.Path)
+		assert.Equal(t, "AAPL", r.URL.Query().Get("symbols"))
+		w.Write([]byte(`{"spark":{"result":[{"symbol":"AAPL","response":[{
+			"meta":{"chartPreviousClose":174.2},
+			"timestamp":[1700000000,1700000300],
+			"indicators":{"quote":[{"close":[174.5,174.8]}]}
+		}]}],"error":null}}`))
+	}))
+	c := Client{B: backend}
+
+	sparks, err := c.GetP(&Params{Symbols: []string{"AAPL"}, Range: "1d", Interval: "5m"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sparks["AAPL"])
+	assert.Equal(t, []float64{174.5, 174.8}, sparks["AAPL"].Close)
+	assert.Equal(t, 174.2, sparks["AAPL"].PreviousClose)
+}
+
+func TestGetSparkNoSymbols(t *testing.T) {
+	sparks, err := Get(nil, "1d", "5m")
+
+	assert.Nil(t, sparks)
+	assert.Equal(t, "code: api-error, detail: missing function argument", err.Error())
+}
+
+func TestGetSparkPartialFailure(t *testing.T) {
+	var queries []string
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		results := []string{}
+		for _, s := range strings.Split(r.URL.Query().Get("symbols"), ",") {
+			if s == "BADSYMBOL" {
+				continue
+			}
+			results = append(results, fmt.Sprintf(`{"symbol":%q,"response":[{
+				"meta":{"chartPreviousClose":100},
+				"timestamp":[1,2,3],
+				"indicators":{"quote":[{"close":[101,null,103]}]}
+			}]}`, s))
+		}
+		fmt.Fprintf(w, `{"spark":{"result":[%s],"error":null}}`, strings.Join(results, ","))
+	}))
+	c := Client{B: backend}
+
+	symbols := []string{"BADSYMBOL"}
+	for i := 0; i < BatchSize; i++ {
+		symbols = append(symbols, fmt.Sprintf("S%d", i))
+	}
+	sparks, err := c.GetP(&Params{Symbols: symbols})
+
+	assert.Len(t, queries, 2)
+	assert.Contains(t, queries[0], "interval=5m")
+	assert.Contains(t, queries[0], "range=1d")
+	assert.Len(t, sparks, BatchSize)
+	assert.Equal(t, &finance.Spark{
+		Symbol:        "S0",
+		Timestamps:    []int{1, 3},
+		Close:         []float64{101, 103},
+		PreviousClose: 100,
+	}, sparks["S0"])
+	assert.Contains(t, err.(finance.ListError), "BADSYMBOL")
+}
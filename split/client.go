@@ -0,0 +1,90 @@
+package split
+
+import (
+	"sort"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/chart"
+	"github.com/fijoyapp/finance-go/datetime"
+	form "github.com/fijoyapp/finance-go/form"
+	"github.com/fijoyapp/finance-go/iter"
+)
+
+// Client is used to invoke split APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and split history information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Symbol string `form:"-"`
+	// Start and End bound the split history.
+	// The full history is requested if Start is nil.
+	Start *datetime.Datetime `form:"-"`
+	End   *datetime.Datetime `form:"-"`
+}
+
+// Iter is a structure containing results
+// and related metadata for a
+// yfin split history request.
+type Iter struct {
+	*iter.Iter
+}
+
+// Split returns the next Split
+// visited by a call to Next.
+func (i *Iter) Split() *finance.Split {
+	return i.Current().(*finance.Split)
+}
+
+// Get returns a split history in chronological order
+// and requires a params struct as an argument.
+func Get(params *Params) *Iter {
+	return getC().Get(params)
+}
+
+// Get returns a split history in chronological order.
+// Symbols that never split yield an empty iterator.
+func (c Client) Get(params *Params) *Iter {
+
+	if params == nil || len(params.Symbol) == 0 {
+		return &Iter{iter.NewE(finance.CreateArgumentError())}
+	}
+
+	return &Iter{iter.New(nil, func(b *form.Values) (interface{}, []interface{}, error) {
+
+		events, err := chart.Client{B: c.B}.GetEvents(&chart.EventsParams{
+			Params: params.Params,
+			Symbol: params.Symbol,
+			Events: "split",
+			Start:  params.Start,
+			End:    params.End,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		splits := make([]*finance.Split, 0, len(events.Splits))
+		for _, s := range events.Splits {
+			splits = append(splits, s)
+		}
+		sort.Slice(splits, func(i, j int) bool {
+			return splits[i].Date.Unix() < splits[j].Date.Unix()
+		})
+
+		values := make([]interface{}, len(splits))
+		for i, s := range splits {
+			values[i] = s
+		}
+
+		return nil, values, nil
+	})}
+}
@@ -0,0 +1,61 @@
+package split
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/fijoyapp/finance-go/datetime"
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient returns a client for a server
+// responding with the given chart events.
+func newTestClient(t *testing.T, events string) Client {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v8/finance/chart/AAPL", r.URL.Path)
+		assert.Equal(t, "split", r.URL.Query().Get("events"))
+		w.Write([]byte(`{"chart":{"result":[{"events":` + events + `}],"error":null}}`))
+	}))
+	return Client{B: backend}
+}
+
+func TestGetSplits(t *testing.T) {
+	c := newTestClient(t, `{"splits":{
+		"1598880600":{"numerator":4,"denominator":1,"splitRatio":"4:1","date":1598880600},
+		"1402061400":{"numerator":7,"denominator":1,"splitRatio":"7:1","date":1402061400}
+	}}`)
+
+	iter := c.Get(&Params{Symbol: "AAPL"})
+
+	assert.True(t, iter.Next())
+	assert.Equal(t, "7:1", iter.Split().Ratio)
+	assert.Equal(t, 7.0, iter.Split().Numerator)
+	assert.Equal(t, 1.0, iter.Split().Denominator)
+	assert.Equal(t, 1402061400, iter.Split().Date.Unix())
+	assert.True(t, iter.Next())
+	assert.Equal(t, "4:1", iter.Split().Ratio)
+	assert.False(t, iter.Next())
+	assert.Nil(t, iter.Err())
+}
+
+func TestGetNoSplits(t *testing.T) {
+	c := newTestClient(t, `null`)
+
+	iter := c.Get(&Params{Symbol: "AAPL"})
+
+	assert.False(t, iter.Next())
+	assert.Nil(t, iter.Err())
+}
+
+func TestBadRangeSplits(t *testing.T) {
+	p := &Params{
+		Symbol: "AAPL",
+		Start:  &datetime.Datetime{Month: 1, Day: 1, Year: 2018},
+		End:    &datetime.Datetime{Month: 1, Day: 1, Year: 2017},
+	}
+	iter := Get(p)
+
+	assert.False(t, iter.Next())
+	assert.NotNil(t, iter.Err())
+}
@@ -0,0 +1,229 @@
+package stream
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	finance "github.com/fijoyapp/finance-go"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// DefaultURL is the yahoo finance streamer.
+	DefaultURL = "wss://streamer.finance.yahoo.com/"
+	// origin is sent with the websocket handshake.
+	origin = "https://finance.yahoo.com"
+	// bufferSize is the number of quotes buffered
+	// before the stream blocks on a slow reader.
+	bufferSize = 64
+)
+
+// Params carries the streamer information.
+type Params struct {
+	// Symbols are the symbols to subscribe to.
+	Symbols []string
+	// URL of the streamer. Defaults to DefaultURL.
+	URL string
+	// ReconnectBackoff returns the delay before a reconnect
+	// attempt. Defaults to finance.DefaultRetryBackoff.
+	ReconnectBackoff func(attempt int) time.Duration
+}
+
+// Stream is a live subscription to price updates. A dropped
+// connection is reestablished automatically and the current
+// symbols are subscribed to again.
+type Stream struct {
+	config  *websocket.Config
+	backoff func(attempt int) time.Duration
+	quotes  chan finance.StreamQuote
+	done    chan struct{}
+	once    sync.Once
+
+	mu      sync.Mutex
+	conn    *websocket.Conn
+	symbols map[string]bool
+}
+
+// Subscribe opens a stream of price updates for symbols.
+func Subscribe(symbols []string) (*Stream, error) {
+	return SubscribeP(&Params{Symbols: symbols})
+}
+
+// SubscribeP opens a stream of price updates
+// and requires a params struct as an argument.
+func SubscribeP(params *Params) (*Stream, error) {
+	if params == nil || len(params.Symbols) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	url := params.URL
+	if url == "" {
+		url = DefaultURL
+	}
+	config, err := websocket.NewConfig(url, origin)
+	if err != nil {
+		return nil, err
+	}
+
+	backoff := params.ReconnectBackoff
+	if backoff == nil {
+		backoff = finance.DefaultRetryBackoff
+	}
+
+	s := &Stream{
+		config:  config,
+		backoff: backoff,
+		quotes:  make(chan finance.StreamQuote, bufferSize),
+		done:    make(chan struct{}),
+		symbols: map[string]bool{},
+	}
+	for _, symbol := range params.Symbols {
+		s.symbols[symbol] = true
+	}
+
+	conn, err := s.connect()
+	if err != nil {
+		return nil, finance.CreateRemoteError(err)
+	}
+
+	go s.run(conn)
+	return s, nil
+}
+
+// Quotes returns the channel of price updates.
+// It is closed once the stream is closed.
+func (s *Stream) Quotes() <-chan finance.StreamQuote {
+	return s.quotes
+}
+
+// Subscribe adds symbols to the stream.
+func (s *Stream) Subscribe(symbols []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, symbol := range symbols {
+		s.symbols[symbol] = true
+	}
+	return websocket.JSON.Send(s.conn, map[string][]string{"subscribe": symbols})
+}
+
+// Unsubscribe removes symbols from the stream.
+func (s *Stream) Unsubscribe(symbols []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, symbol := range symbols {
+		delete(s.symbols, symbol)
+	}
+	return websocket.JSON.Send(s.conn, map[string][]string{"unsubscribe": symbols})
+}
+
+// Close closes the stream and its quotes channel.
+func (s *Stream) Close() error {
+	var err error
+	s.once.Do(func() {
+		close(s.done)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		err = s.conn.Close()
+	})
+	return err
+}
+
+// connect dials the streamer and subscribes to the current symbols.
+func (s *Stream) connect() (*websocket.Conn, error) {
+	conn, err := websocket.DialConfig(s.config)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		conn.Close()
+		return nil, nil
+	default:
+	}
+
+	symbols := make([]string, 0, len(s.symbols))
+	for symbol := range s.symbols {
+		symbols = append(symbols, symbol)
+	}
+	if err := websocket.JSON.Send(conn, map[string][]string{"subscribe": symbols}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	s.conn = conn
+	return conn, nil
+}
+
+// run reads updates until the stream is closed,
+// reconnecting whenever the connection drops.
+func (s *Stream) run(conn *websocket.Conn) {
+	defer close(s.quotes)
+
+	for conn != nil {
+		s.read(conn)
+		conn = s.reconnect()
+	}
+}
+
+// read pushes updates from conn onto
+// the quotes channel until conn fails.
+func (s *Stream) read(conn *websocket.Conn) {
+	for {
+		var msg string
+		if err := websocket.Message.Receive(conn, &msg); err != nil {
+			select {
+			case <-s.done:
+				// The connection was closed by Close.
+				return
+			default:
+			}
+			finance.LogEvent(context.Background(), slog.LevelError, "Stream connection lost",
+				"url", s.config.Location.String(), "error", err)
+			return
+		}
+
+		q, err := decodeMessage(msg)
+		if err != nil {
+			finance.LogEvent(context.Background(), slog.LevelError, "Cannot decode stream message",
+				"url", s.config.Location.String(), "error", err)
+			continue
+		}
+		if q == nil {
+			continue
+		}
+
+		select {
+		case s.quotes <- *q:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// reconnect dials the streamer with backoff until it succeeds,
+// returning nil if the stream is closed in the meantime.
+func (s *Stream) reconnect() *websocket.Conn {
+	for attempt := 0; ; attempt++ {
+		select {
+		case <-s.done:
+			return nil
+		case <-time.After(s.backoff(attempt)):
+		}
+
+		conn, err := s.connect()
+		if err == nil {
+			return conn
+		}
+		finance.LogEvent(context.Background(), slog.LevelError, "Stream reconnect failed",
+			"url", s.config.Location.String(), "retry_count", attempt+1, "error", err)
+	}
+}
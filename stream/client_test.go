@@ -0,0 +1,148 @@
+package stream
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/websocket"
+)
+
+// pricingData encodes a PricingData protobuf message
+// with a symbol, price, time and day volume.
+func pricingData(symbol string, price float32, time, volume int64) string {
+	b := []byte{}
+	b = binary.AppendUvarint(b, 1<<3|wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(symbol)))
+	b = append(b, symbol...)
+	b = binary.AppendUvarint(b, 2<<3|wireFixed32)
+	b = binary.LittleEndian.AppendUint32(b, math.Float32bits(price))
+	b = binary.AppendUvarint(b, 3<<3|wireVarint)
+	b = binary.AppendUvarint(b, uint64(time<<1^time>>63))
+	b = binary.AppendUvarint(b, 9<<3|wireVarint)
+	b = binary.AppendUvarint(b, uint64(volume<<1^volume>>63))
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func TestDecodeMessage(t *testing.T) {
+	msg := pricingData("AAPL", 150.25, 1700000000000, 12345)
+
+	q, err := decodeMessage(msg)
+	assert.Nil(t, err)
+	assert.Equal(t, &finance.StreamQuote{
+		Symbol:    "AAPL",
+		Price:     150.25,
+		Time:      1700000000000,
+		DayVolume: 12345,
+	}, q)
+
+	q, err = decodeMessage(`{"type":"pricing","message":"` + msg + `"}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "AAPL", q.Symbol)
+
+	_, err = decodeMessage(base64.StdEncoding.EncodeToString([]byte{1<<3 | wireBytes, 10, 'A'}))
+	assert.Equal(t, errMalformed, err)
+}
+
+func TestSubscribeNoSymbols(t *testing.T) {
+	s, err := Subscribe(nil)
+	assert.Nil(t, s)
+	assert.EqualError(t, err, "code: api-error, detail: missing function argument")
+}
+
+func TestStreamReconnect(t *testing.T) {
+	subscribed := make(chan string, 2)
+	var connections int32
+	server := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		n := atomic.AddInt32(&connections, 1)
+		var sub map[string][]string
+		websocket.JSON.Receive(ws, &sub)
+		subscribed <- strings.Join(sub["subscribe"], ",")
+
+		websocket.Message.Send(ws, pricingData("AAPL", float32(n), 0, 0))
+		if n == 1 {
+			// Drop the first connection.
+			return
+		}
+		var msg string
+		websocket.Message.Receive(ws, &msg)
+	}))
+	defer server.Close()
+
+	s, err := SubscribeP(&Params{
+		Symbols:          []string{"AAPL"},
+		URL:              "ws" + strings.TrimPrefix(server.URL, "http"),
+		ReconnectBackoff: func(int) time.Duration { return time.Millisecond },
+	})
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1.0, (<-s.Quotes()).Price)
+	assert.Equal(t, 2.0, (<-s.Quotes()).Price)
+	assert.Equal(t, "AAPL", <-subscribed)
+	assert.Equal(t, "AAPL", <-subscribed)
+
+	s.Close()
+	_, ok := <-s.Quotes()
+	assert.False(t, ok)
+}
+
+func TestStreamLogging(t *testing.T) {
+	buf := &bytes.Buffer{}
+	var mu sync.Mutex
+	finance.SetLogger(slog.New(slog.NewJSONHandler(lockedWriter{&mu, buf}, nil)))
+	defer finance.SetLogger(nil)
+
+	server := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		var sub map[string][]string
+		websocket.JSON.Receive(ws, &sub)
+		websocket.Message.Send(ws, "not base64!")
+		websocket.Message.Send(ws, pricingData("AAPL", 1, 0, 0))
+		var msg string
+		websocket.Message.Receive(ws, &msg)
+	}))
+	defer server.Close()
+
+	s, err := SubscribeP(&Params{
+		Symbols: []string{"AAPL"},
+		URL:     "ws" + strings.TrimPrefix(server.URL, "http"),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1.0, (<-s.Quotes()).Price)
+	s.Close()
+	for range s.Quotes() {
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.NotContains(t, buf.String(), "Stream connection lost")
+	var record map[string]interface{}
+	assert.Nil(t, json.NewDecoder(buf).Decode(&record))
+	assert.Equal(t, "Cannot decode stream message", record["msg"])
+	assert.Equal(t, "ERROR", record["level"])
+	assert.Contains(t, record["url"], "ws://127.0.0.1")
+	assert.Contains(t, record, "error")
+}
+
+// lockedWriter serializes writes to w.
+type lockedWriter struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func (l lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
@@ -0,0 +1,142 @@
+package stream
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+
+	finance "github.com/fijoyapp/finance-go"
+)
+
+// errMalformed is returned for truncated or invalid pricing messages.
+var errMalformed = errors.New("stream: malformed pricing message")
+
+// Protobuf wire types used by pricing messages.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// decodeMessage decodes a streamer message, which is either a
+// base64 encoded PricingData protobuf or a JSON envelope
+// like {"type":"pricing","message":"<base64>"} carrying one.
+func decodeMessage(msg string) (*finance.StreamQuote, error) {
+	if len(msg) > 0 && msg[0] == '{' {
+		envelope := struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		}{}
+		if err := json.Unmarshal([]byte(msg), &envelope); err != nil {
+			return nil, err
+		}
+		if envelope.Type != "pricing" {
+			return nil, nil
+		}
+		msg = envelope.Message
+	}
+
+	b, err := base64.StdEncoding.DecodeString(msg)
+	if err != nil {
+		return nil, err
+	}
+	return decodePricingData(b)
+}
+
+// decodePricingData decodes the fields of yahoo's PricingData
+// protobuf message that are exposed on StreamQuote.
+func decodePricingData(b []byte) (*finance.StreamQuote, error) {
+	q := &finance.StreamQuote{}
+
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, errMalformed
+		}
+		b = b[n:]
+		field, wire := key>>3, key&7
+
+		switch wire {
+		case wireVarint:
+			v, n := binary.Uvarint(b)
+			if n <= 0 {
+				return nil, errMalformed
+			}
+			b = b[n:]
+			// Integers are sint64, so zigzag encoded,
+			// except for the enums.
+			s := int64(v>>1) ^ -int64(v&1)
+			switch field {
+			case 3:
+				q.Time = s
+			case 6:
+				q.QuoteType = int(v)
+			case 7:
+				q.MarketHours = int(v)
+			case 9:
+				q.DayVolume = s
+			case 22:
+				q.LastSize = s
+			case 24:
+				q.BidSize = s
+			case 26:
+				q.AskSize = s
+			}
+		case wireFixed32:
+			if len(b) < 4 {
+				return nil, errMalformed
+			}
+			f := float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+			b = b[4:]
+			switch field {
+			case 2:
+				q.Price = f
+			case 8:
+				q.ChangePercent = f
+			case 10:
+				q.DayHigh = f
+			case 11:
+				q.DayLow = f
+			case 12:
+				q.Change = f
+			case 15:
+				q.OpenPrice = f
+			case 16:
+				q.PreviousClose = f
+			case 23:
+				q.Bid = f
+			case 25:
+				q.Ask = f
+			}
+		case wireBytes:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < l {
+				return nil, errMalformed
+			}
+			s := string(b[n : n+int(l)])
+			b = b[n+int(l):]
+			switch field {
+			case 1:
+				q.Symbol = s
+			case 4:
+				q.Currency = s
+			case 5:
+				q.Exchange = s
+			case 13:
+				q.ShortName = s
+			}
+		case wireFixed64:
+			if len(b) < 8 {
+				return nil, errMalformed
+			}
+			b = b[8:]
+		default:
+			return nil, errMalformed
+		}
+	}
+
+	return q, nil
+}
@@ -1 +1,110 @@
 package symbols
+
+import (
+	"context"
+	"strings"
+
+	finance "github.com/fijoyapp/finance-go"
+	form "github.com/fijoyapp/finance-go/form"
+)
+
+// BatchSize is the number of symbols validated per request.
+const BatchSize = 100
+
+// Client is used to invoke symbol APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and symbols information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Symbols []string `form:"-"`
+
+	// Internal request fields.
+	sym string `form:"symbols"`
+}
+
+// Validate reports which symbols exist, keyed by symbol.
+func Validate(symbols []string) (map[string]bool, error) {
+	return ValidateP(&Params{Symbols: symbols})
+}
+
+// ValidateP reports which symbols exist and
+// requires a params struct as an argument.
+func ValidateP(params *Params) (map[string]bool, error) {
+	return getC().ValidateP(params)
+}
+
+// ValidateP reports which symbols exist, keyed by symbol,
+// requesting them in batches of BatchSize.
+func (c Client) ValidateP(params *Params) (map[string]bool, error) {
+	if params == nil || len(params.Symbols) == 0 {
+		return nil, finance.CreateArgumentError()
+	}
+
+	if params.Context == nil {
+		ctx := context.TODO()
+		params.Context = &ctx
+	}
+
+	valid := make(map[string]bool, len(params.Symbols))
+	for start := 0; start < len(params.Symbols); start += BatchSize {
+		end := start + BatchSize
+		if end > len(params.Symbols) {
+			end = len(params.Symbols)
+		}
+		batch := params.Symbols[start:end]
+
+		results, err := c.validate(params, batch)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range batch {
+			valid[s] = results[strings.ToUpper(s)]
+		}
+	}
+
+	return valid, nil
+}
+
+// validate validates a single batch of symbols,
+// keyed by the upper case symbol.
+func (c Client) validate(params *Params, symbols []string) (map[string]bool, error) {
+	params.sym = strings.Join(symbols, ",")
+
+	body := &form.Values{}
+	form.AppendTo(body, params)
+
+	resp := response{}
+	err := c.B.Call("v6/finance/quote/validate", body, params.Context, &resp)
+	if err != nil {
+		return nil, finance.CreateRemoteError(err)
+	}
+	if resp.Inner.Error != nil {
+		return nil, finance.CreateRemoteError(resp.Inner.Error)
+	}
+
+	results := map[string]bool{}
+	for _, r := range resp.Inner.Results {
+		for s, ok := range r {
+			results[strings.ToUpper(s)] = ok
+		}
+	}
+	return results, nil
+}
+
+// response is a yfin symbol validation response.
+type response struct {
+	Inner struct {
+		Results []map[string]bool  `json:"result"`
+		Error   *finance.YfinError `json:"error"`
+	} `json:"symbolsValidation"`
+}
@@ -1 +1,58 @@
 package symbols
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v6/finance/quote/validate", r.URL.Path)
+		assert.Equal(t, "aapl,BADSYMBOL", r.URL.Query().Get("symbols"))
+		w.Write([]byte(`{"symbolsValidation":{"result":[{"AAPL":true,"BADSYMBOL":false}],"error":null}}`))
+	}))
+	c := Client{B: backend}
+
+	valid, err := c.ValidateP(&Params{Symbols: []string{"aapl", "BADSYMBOL"}})
+
+	assert.Nil(t, err)
+	assert.True(t, valid["aapl"])
+	assert.False(t, valid["BADSYMBOL"])
+}
+
+func TestValidateNoSymbols(t *testing.T) {
+	valid, err := Validate(nil)
+
+	assert.Nil(t, valid)
+	assert.Equal(t, "code: api-error, detail: missing function argument", err.Error())
+}
+
+func TestValidateBatches(t *testing.T) {
+	var batches int
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		batches++
+		results := []string{}
+		for _, s := range strings.Split(r.URL.Query().Get("symbols"), ",") {
+			results = append(results, fmt.Sprintf(`%q:%t`, s, !strings.HasPrefix(s, "BAD")))
+		}
+		fmt.Fprintf(w, `{"symbolsValidation":{"result":[{%s}],"error":null}}`, strings.Join(results, ","))
+	}))
+	c := Client{B: backend}
+
+	symbols := []string{"BAD0"}
+	for i := 1; i <= BatchSize; i++ {
+		symbols = append(symbols, fmt.Sprintf("S%d", i))
+	}
+	valid, err := c.ValidateP(&Params{Symbols: symbols})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, batches)
+	assert.Len(t, valid, BatchSize+1)
+	assert.False(t, valid["BAD0"])
+	assert.True(t, valid["S100"])
+}
@@ -5,10 +5,14 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/financetest"
 	"github.com/fijoyapp/finance-go/form"
 )
 
@@ -38,6 +42,23 @@ const (
 	TestYear             = 2018
 )
 
+// Market states of the test server, each with its own recordings.
+const (
+	stateRegular = "regular"
+	statePre     = "pre"
+	statePost    = "post"
+)
+
+var (
+	// mockURL is the address of finance-mock.
+	mockURL string
+	// recordings holds the recorders of each market state, and of
+	// the yahoo session requests under the "session" key.
+	recordings map[string]*financetest.Recorder
+	// state is the current market state of the test server.
+	state atomic.Value
+)
+
 func init() {
 	// Enable strict mode on form encoding so that we'll panic if any kind of
 	// malformed param struct is detected
@@ -47,11 +68,50 @@ func init() {
 	if port == "" {
 		port = "12111"
 	}
+	mockURL = "http://" + TestServerAddr + ":" + port
+
+	// Responses are replayed from the recordings in testdata next to
+	// this file when there is one, and requested from finance-mock
+	// otherwise. With financetest.RecordEnv set, every response is
+	// requested and recorded again.
+	_, file, _, _ := runtime.Caller(0)
+	dir := filepath.Join(filepath.Dir(file), "testdata")
+	recordings = map[string]*financetest.Recorder{}
+	for _, name := range []string{stateRegular, statePre, statePost, "session"} {
+		r := financetest.NewRecorder(filepath.Join(dir, name))
+		r.Fallback = http.DefaultTransport
+		recordings[name] = r
+	}
+	state.Store(stateRegular)
+
+	if recordings[stateRegular].Record {
+		checkMock()
+	}
+
+	finance.SetBackend(finance.YFinBackend, &finance.BackendConfiguration{
+		Type:       finance.YFinBackend,
+		URL:        mockURL,
+		HTTPClient: &http.Client{Transport: transport{}},
+	})
+}
+
+// transport replays, or records, the responses of the test server
+// for the current market state, and those of the yahoo session.
+type transport struct{}
+
+func (transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if "http://"+req.URL.Host != mockURL {
+		return recordings["session"].RoundTrip(req)
+	}
+	return recordings[state.Load().(string)].RoundTrip(req)
+}
 
-	resp, err := http.Get("http://" + TestServerAddr + ":" + port)
+// checkMock exits if finance-mock can't be reached or is too old.
+func checkMock() {
+	resp, err := http.Get(mockURL)
 	if err != nil || resp.StatusCode != http.StatusOK {
-		fmt.Fprintf(os.Stderr, "Couldn't reach finance-mock at `%s:%s`. Is "+
-			"it running? Please see README for setup instructions.\n", TestServerAddr, port)
+		fmt.Fprintf(os.Stderr, "Couldn't reach finance-mock at `%s`. Is "+
+			"it running? Please see README for setup instructions.\n", mockURL)
 		os.Exit(1)
 	}
 
@@ -62,46 +122,42 @@ func init() {
 			"repository for upgrade instructions.\n", version, MockMinimumVersion)
 		os.Exit(1)
 	}
-
-	finance.SetBackend(finance.YFinBackend, &finance.BackendConfiguration{
-		Type:       finance.YFinBackend,
-		URL:        "http://" + TestServerAddr + ":" + port,
-		HTTPClient: &http.Client{},
-	})
 }
 
 // SetMarket sets the test server to the state/session specified.
-func SetMarket(state finance.MarketState) {
+// Responses are then replayed from the recordings of that state.
+// Changing the state of finance-mock is only required to record.
+func SetMarket(mktState finance.MarketState) {
 	// one of regular/post/pre
-	var mktState string
+	name := stateRegular
 
-	switch state {
+	switch mktState {
 	case finance.MarketStatePre,
 		finance.MarketStatePrePre:
-		mktState = "pre"
+		name = statePre
 	case finance.MarketStatePost,
 		finance.MarketStatePostPost:
-		mktState = "post"
-	default:
-		mktState = "regular"
+		name = statePost
 	}
+	state.Store(name)
 
 	form := url.Values{}
-	form.Add("state", mktState)
+	form.Add("state", name)
 
 	// Post.
-	resp, err := http.PostForm("http://localhost:12111/config/", form)
+	resp, err := http.PostForm(mockURL+"/config/", form)
 	if err != nil || resp.StatusCode != http.StatusOK {
-
-		fmt.Println(resp.Request.Form)
-
+		if !recordings[name].Record {
+			return
+		}
 		fmt.Fprintf(os.Stderr, "Couldn't change state of finance-mock. Is "+
 			"it running? Please see README for setup instructions.\n")
 		os.Exit(1)
 	}
+	resp.Body.Close()
 
 	// Success.
-	fmt.Fprintf(os.Stdout, "Changed state of finance-mock to %s.\n", mktState)
+	fmt.Fprintf(os.Stdout, "Changed state of finance-mock to %s.\n", name)
 }
 
 // compareVersions compares two semantic version strings. We need this because
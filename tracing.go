@@ -0,0 +1,88 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation name of the spans.
+const tracerName = "github.com/fijoyapp/finance-go"
+
+// symbolPrefixes are the paths of endpoints
+// taking a single symbol as their last segment.
+var symbolPrefixes = []string{
+	"/v8/finance/chart/",
+	"/v10/finance/quoteSummary/",
+	YOptionsPrefix,
+}
+
+// startSpan starts a span for an api request using the tracer of
+// the span in the request's context, if any. It returns the request
+// carrying the new span, and is a no-op if tracing isn't configured.
+func startSpan(req *http.Request) (*http.Request, trace.Span) {
+	parent := trace.SpanFromContext(req.Context())
+	if !parent.SpanContext().IsValid() {
+		return req, trace.SpanFromContext(context.Background())
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", req.Method),
+		attribute.String("url.path", req.URL.Path),
+	}
+	if n := symbolCount(req); n > 0 {
+		attrs = append(attrs, attribute.Int("finance.symbol_count", n))
+	}
+
+	ctx, span := parent.TracerProvider().Tracer(tracerName).Start(req.Context(), "finance "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	return req.WithContext(ctx), span
+}
+
+// endSpan records the outcome of an api request on its span and ends
+// it. Responses served from the cache are marked as such, without a
+// status code, since no request was made.
+func endSpan(span trace.Span, cached bool, err error) {
+	defer span.End()
+	if !span.IsRecording() {
+		return
+	}
+
+	if cached {
+		span.SetAttributes(attribute.Bool("finance.cache_hit", true))
+		return
+	}
+
+	if err == nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", http.StatusOK))
+		return
+	}
+
+	var remoteErr *RemoteError
+	if errors.As(err, &remoteErr) {
+		span.SetAttributes(attribute.Int("http.response.status_code", remoteErr.StatusCode))
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// symbolCount returns the number of symbols an api request is for,
+// or 0 if it isn't for specific symbols.
+func symbolCount(req *http.Request) int {
+	if symbols := req.URL.Query().Get("symbols"); symbols != "" {
+		return len(strings.Split(symbols, ","))
+	}
+	for _, prefix := range symbolPrefixes {
+		if strings.HasPrefix(req.URL.Path, prefix) && len(req.URL.Path) > len(prefix) {
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,120 @@
+package trending
+
+import (
+	"context"
+	"encoding/json"
+
+	finance "github.com/fijoyapp/finance-go"
+	form "github.com/fijoyapp/finance-go/form"
+)
+
+// DefaultRegion is the region used when none is specified.
+const DefaultRegion = "US"
+
+// Client is used to invoke trending APIs.
+type Client struct {
+	B finance.Backend
+}
+
+func getC() Client {
+	return Client{finance.GetBackend(finance.YFinBackend)}
+}
+
+// Params carries a context and region information.
+type Params struct {
+	// Context access.
+	finance.Params `form:"-"`
+
+	// Accessible fields.
+	Region string `form:"-"`
+	Count  int    `form:"count"`
+}
+
+// Get returns the trending symbols in a region, defaulting to the
+// region set with finance.SetRegion, or the US, if region is empty.
+func Get(region string) (*finance.TrendingResult, error) {
+	return GetP(&Params{Region: region})
+}
+
+// GetP returns trending symbols and requires a params
+// struct as an argument.
+func GetP(params *Params) (*finance.TrendingResult, error) {
+	return getC().GetP(params)
+}
+
+// GetP returns trending symbols.
+func (c Client) GetP(params *Params) (*finance.TrendingResult, error) {
+
+	if params == nil {
+		return nil, finance.CreateArgumentError()
+	}
+
+	if params.Context == nil {
+		ctx := context.TODO()
+		params.Context = &ctx
+	}
+
+	// The region set with finance.SetRegion
+	// applies if params doesn't name one.
+	region := params.Region
+	if region == "" {
+		region = finance.GetRegion()
+	}
+	if region == "" {
+		region = DefaultRegion
+	}
+
+	body := &form.Values{}
+	form.AppendTo(body, params)
+	finance.AppendLocale(body)
+	// The region query value must not contradict the path.
+	if len(body.Get("region")) > 0 {
+		body.Set("region", region)
+	}
+
+	resp := response{}
+	err := c.B.Call("v1/finance/trending/"+region, body, params.Context, &resp)
+	if err != nil {
+		// Unknown regions are rejected with an
+		// error status, but still carry a yfin error.
+		if remoteErr, ok := err.(*finance.RemoteError); ok {
+			if json.Unmarshal([]byte(remoteErr.Body), &resp) == nil && resp.Inner.Error != nil {
+				return nil, finance.CreateRemoteError(resp.Inner.Error)
+			}
+		}
+		return nil, finance.CreateRemoteError(err)
+	}
+
+	if resp.Inner.Error != nil {
+		return nil, finance.CreateRemoteError(resp.Inner.Error)
+	}
+
+	if len(resp.Inner.Results) == 0 {
+		return nil, finance.CreateRemoteErrorS("no results in trending response")
+	}
+
+	result := resp.Inner.Results[0]
+	trending := &finance.TrendingResult{
+		Region:  region,
+		Count:   result.Count,
+		Symbols: make([]string, len(result.Quotes)),
+	}
+	for i, q := range result.Quotes {
+		trending.Symbols[i] = q.Symbol
+	}
+
+	return trending, nil
+}
+
+// response is a yfin trending response.
+type response struct {
+	Inner struct {
+		Results []struct {
+			Count  int `json:"count"`
+			Quotes []struct {
+				Symbol string `json:"symbol"`
+			} `json:"quotes"`
+		} `json:"result"`
+		Error *finance.YfinError `json:"error"`
+	} `json:"finance"`
+}
@@ -0,0 +1,65 @@
+package trending
+
+import (
+	"net/http"
+	"testing"
+
+	finance "github.com/fijoyapp/finance-go"
+	"github.com/fijoyapp/finance-go/financetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTrending(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/finance/trending/"+DefaultRegion, r.URL.Path)
+		w.Write([]byte(`{"finance":{"result":[{"count":2,"quotes":[{"symbol":"NVDA"},{"symbol":"TSLA"}]}],"error":null}}`))
+	}))
+	c := Client{B: backend}
+
+	result, err := c.GetP(&Params{})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, DefaultRegion, result.Region)
+	assert.Equal(t, result.Count, len(result.Symbols))
+	assert.Equal(t, []string{"NVDA", "TSLA"}, result.Symbols)
+}
+
+func TestGetTrendingBadRegion(t *testing.T) {
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/finance/trending/XX", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"finance":{"result":null,"error":{"code":"Not Found","description":"No data found for region XX"}}}`))
+	}))
+	c := Client{B: backend}
+
+	result, err := c.GetP(&Params{Region: "XX"})
+
+	assert.Nil(t, result)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "No data found for region XX")
+}
+
+func TestGetTrendingPackageRegion(t *testing.T) {
+	var paths, regions []string
+	backend := financetest.NewServerBackend(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		regions = append(regions, r.URL.Query().Get("region"))
+		w.Write([]byte(`{"finance":{"result":[{"count":0,"quotes":[]}],"error":null}}`))
+	}))
+	c := Client{B: backend}
+
+	finance.SetRegion("GB")
+	defer finance.SetRegion("")
+
+	result, err := c.GetP(&Params{})
+	assert.Nil(t, err)
+	assert.Equal(t, "GB", result.Region)
+
+	result, err = c.GetP(&Params{Region: "FR"})
+	assert.Nil(t, err)
+	assert.Equal(t, "FR", result.Region)
+
+	assert.Equal(t, []string{"/v1/finance/trending/GB", "/v1/finance/trending/FR"}, paths)
+	assert.Equal(t, []string{"GB", "FR"}, regions)
+}
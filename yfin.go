@@ -3,7 +3,10 @@ package finance
 import (
 	"context"
 	"encoding/json"
+	"strings"
+	"time"
 
+	"github.com/fijoyapp/finance-go/datetime"
 	"github.com/shopspring/decimal"
 )
 
@@ -24,6 +27,11 @@ func (e *YfinError) Error() string {
 	return string(ret)
 }
 
+// Unwrap returns the sentinel errors matching the error code.
+func (e *YfinError) Unwrap() []error {
+	return sentinels(0, e.Code, e.Description)
+}
+
 type (
 	// QuoteType alias for asset classification.
 	QuoteType string
@@ -146,6 +154,16 @@ type Future struct {
 	IsContractSymbol         bool    `json:"contractSymbol" csv:"contractSymbol"`
 }
 
+// Expiration returns the expiration date of the futures
+// contract, or nil if the quote doesn't carry one, such
+// as for a continuous contract like CL=F.
+func (f *Future) Expiration() *datetime.Datetime {
+	if f.ExpireDate == 0 {
+		return nil
+	}
+	return datetime.FromUnix(f.ExpireDate)
+}
+
 // ForexPair represents a single forex currency pair quote.
 type ForexPair struct {
 	Quote
@@ -161,6 +179,10 @@ type CryptoPair struct {
 	CirculatingSupply   int    `json:"circulatingSupply" csv:"circulatingSupply"`
 	VolumeLastDay       int    `json:"volume24Hr" csv:"volume24Hr"`
 	VolumeAllCurrencies int    `json:"volumeAllCurrencies" csv:"volumeAllCurrencies"`
+	MarketCap           int64  `json:"marketCap" csv:"marketCap"`
+	FromCurrency        string `json:"fromCurrency" csv:"fromCurrency"`
+	ToCurrency          string `json:"toCurrency" csv:"toCurrency"`
+	LastMarket          string `json:"lastMarket" csv:"lastMarket"`
 }
 
 // Quote is the basic quote structure shared across
@@ -192,17 +214,19 @@ type Quote struct {
 	BidSize int     `json:"bidSize" csv:"bidSize"`
 	AskSize int     `json:"askSize" csv:"askSize"`
 
-	// Pre-market quote data.
-	PreMarketPrice         float64 `json:"preMarketPrice" csv:"preMarketPrice"`
-	PreMarketChange        float64 `json:"preMarketChange" csv:"preMarketChange"`
-	PreMarketChangePercent float64 `json:"preMarketChangePercent" csv:"preMarketChangePercent"`
-	PreMarketTime          int     `json:"preMarketTime" csv:"preMarketTime"`
+	// Pre-market quote data. The time is nil
+	// outside of the pre-market session.
+	PreMarketPrice         float64            `json:"preMarketPrice" csv:"preMarketPrice"`
+	PreMarketChange        float64            `json:"preMarketChange" csv:"preMarketChange"`
+	PreMarketChangePercent float64            `json:"preMarketChangePercent" csv:"preMarketChangePercent"`
+	PreMarketTime          *datetime.Datetime `json:"preMarketTime" csv:"preMarketTime"`
 
-	// Post-market quote data.
-	PostMarketPrice         float64 `json:"postMarketPrice" csv:"postMarketPrice"`
-	PostMarketChange        float64 `json:"postMarketChange" csv:"postMarketChange"`
-	PostMarketChangePercent float64 `json:"postMarketChangePercent" csv:"postMarketChangePercent"`
-	PostMarketTime          int     `json:"postMarketTime" csv:"postMarketTime"`
+	// Post-market quote data. The time is nil
+	// until the post-market session starts.
+	PostMarketPrice         float64            `json:"postMarketPrice" csv:"postMarketPrice"`
+	PostMarketChange        float64            `json:"postMarketChange" csv:"postMarketChange"`
+	PostMarketChangePercent float64            `json:"postMarketChangePercent" csv:"postMarketChangePercent"`
+	PostMarketTime          *datetime.Datetime `json:"postMarketTime" csv:"postMarketTime"`
 
 	// 52wk ranges.
 	FiftyTwoWeekLowChange         float64 `json:"fiftyTwoWeekLowChange" csv:"fiftyTwoWeekLowChange"`
@@ -211,6 +235,8 @@ type Quote struct {
 	FiftyTwoWeekHighChangePercent float64 `json:"fiftyTwoWeekHighChangePercent" csv:"fiftyTwoWeekHighChangePercent"`
 	FiftyTwoWeekLow               float64 `json:"fiftyTwoWeekLow" csv:"fiftyTwoWeekLow"`
 	FiftyTwoWeekHigh              float64 `json:"fiftyTwoWeekHigh" csv:"fiftyTwoWeekHigh"`
+	FiftyTwoWeekRange             string  `json:"fiftyTwoWeekRange" csv:"fiftyTwoWeekRange"`
+	FiftyTwoWeekChangePercent     float64 `json:"fiftyTwoWeekChangePercent" csv:"fiftyTwoWeekChangePercent"`
 
 	// Averages.
 	FiftyDayAverage                   float64 `json:"fiftyDayAverage" csv:"fiftyDayAverage"`
@@ -238,6 +264,49 @@ type Quote struct {
 	ExchangeID                string `json:"exchange" csv:"exchange"`
 }
 
+// AssetClass returns the quote type normalized to one of the QuoteType
+// constants, since yahoo is inconsistent in casing and spacing,
+// for example "EQUITY", "Equity" or "Mutual Fund".
+func (q *Quote) AssetClass() QuoteType {
+	return QuoteType(strings.NewReplacer(" ", "", "_", "").Replace(strings.ToUpper(strings.TrimSpace(string(q.QuoteType)))))
+}
+
+// IsEquity reports whether the quote is for an equity.
+func (q *Quote) IsEquity() bool { return q.AssetClass() == QuoteTypeEquity }
+
+// IsETF reports whether the quote is for an etf.
+func (q *Quote) IsETF() bool { return q.AssetClass() == QuoteTypeETF }
+
+// IsMutualFund reports whether the quote is for a mutual fund.
+func (q *Quote) IsMutualFund() bool { return q.AssetClass() == QuoteTypeMutualFund }
+
+// IsIndex reports whether the quote is for an index.
+func (q *Quote) IsIndex() bool { return q.AssetClass() == QuoteTypeIndex }
+
+// IsOption reports whether the quote is for an option contract.
+func (q *Quote) IsOption() bool { return q.AssetClass() == QuoteTypeOption }
+
+// IsFuture reports whether the quote is for a futures contract.
+func (q *Quote) IsFuture() bool { return q.AssetClass() == QuoteTypeFuture }
+
+// IsForexPair reports whether the quote is for a forex pair.
+func (q *Quote) IsForexPair() bool { return q.AssetClass() == QuoteTypeForexPair }
+
+// IsCrypto reports whether the quote is for a crypto pair.
+func (q *Quote) IsCrypto() bool { return q.AssetClass() == QuoteTypeCryptoPair }
+
+// Delay returns how long the exchange data of the quote is delayed by,
+// from QuoteDelay, which yahoo reports in minutes. It is 0 for
+// real time quotes; QuoteSource names the source of the quote.
+func (q *Quote) Delay() time.Duration {
+	return time.Duration(q.QuoteDelay) * time.Minute
+}
+
+// IsDelayed reports whether the exchange data of the quote is delayed,
+// that is whether yahoo reports a positive exchangeDataDelayedBy.
+// Delayed quotes should not be presented as live data.
+func (q *Quote) IsDelayed() bool { return q.QuoteDelay > 0 }
+
 // ChartBar is a single instance of a chart bar.
 type ChartBar struct {
 	Open      decimal.Decimal
@@ -247,6 +316,11 @@ type ChartBar struct {
 	AdjClose  decimal.Decimal
 	Volume    int
 	Timestamp int
+	// Time is Timestamp localized to the exchange timezone.
+	Time time.Time
+	// Incomplete reports whether the bar was resampled
+	// from a trailing bucket that isn't over yet.
+	Incomplete bool
 }
 
 // OHLCHistoric is a historical quotation.
@@ -258,6 +332,8 @@ type OHLCHistoric struct {
 	AdjClose  float64
 	Volume    int
 	Timestamp int
+	// Time is Timestamp localized to the exchange timezone.
+	Time time.Time
 }
 
 // ChartMeta is meta data associated with a chart response.
@@ -295,6 +371,18 @@ type ChartMeta struct {
 	ValidRanges     []string `json:"validRanges" csv:"-"`
 }
 
+// Location returns the exchange timezone of the chart,
+// falling back to a fixed zone built from the gmtoffset
+// when the timezone name is unknown.
+func (m *ChartMeta) Location() *time.Location {
+	if m.ExchangeTimezoneName != "" {
+		if l, err := time.LoadLocation(m.ExchangeTimezoneName); err == nil {
+			return l
+		}
+	}
+	return time.FixedZone(m.Timezone, m.Gmtoffset)
+}
+
 // OptionsMeta is meta data associated with an options response.
 type OptionsMeta struct {
 	UnderlyingSymbol   string    `json:"underlyingSymbol" csv:"underlyingSymbol"`
@@ -312,21 +400,773 @@ type Straddle struct {
 	Put    *Contract `json:"put,omitempty" csv:"put_,inline"`
 }
 
+// ContractType is the kind of an option contract.
+type ContractType string
+
+const (
+	// ContractTypeCall is a call option contract.
+	ContractTypeCall ContractType = "call"
+	// ContractTypePut is a put option contract.
+	ContractTypePut ContractType = "put"
+)
+
 // Contract is a struct containing a single option contract, usually part of a chain.
 type Contract struct {
-	Symbol            string  `json:"contractSymbol" csv:"contractSymbol"`
-	Strike            float64 `json:"strike" csv:"strike"`
-	Currency          string  `json:"currency" csv:"currency"`
-	LastPrice         float64 `json:"lastPrice" csv:"lastPrice"`
-	Change            float64 `json:"change" csv:"change"`
-	PercentChange     float64 `json:"percentChange" csv:"percentChange"`
-	Volume            int     `json:"volume" csv:"volume"`
-	OpenInterest      int     `json:"openInterest" csv:"openInterest"`
-	Bid               float64 `json:"bid" csv:"bid"`
-	Ask               float64 `json:"ask" csv:"ask"`
-	Size              string  `json:"contractSize" csv:"contractSize"`
-	Expiration        int     `json:"expiration" csv:"expiration"`
-	LastTradeDate     int     `json:"lastTradeDate" csv:"lastTradeDate"`
-	ImpliedVolatility float64 `json:"impliedVolatility" csv:"impliedVolatility"`
-	InTheMoney        bool    `json:"inTheMoney" csv:"inTheMoney"`
+	Symbol            string       `json:"contractSymbol" csv:"contractSymbol"`
+	Type              ContractType `json:"-" csv:"type"`
+	Strike            float64      `json:"strike" csv:"strike"`
+	Currency          string       `json:"currency" csv:"currency"`
+	LastPrice         float64      `json:"lastPrice" csv:"lastPrice"`
+	Change            float64      `json:"change" csv:"change"`
+	PercentChange     float64      `json:"percentChange" csv:"percentChange"`
+	Volume            int          `json:"volume" csv:"volume"`
+	OpenInterest      int          `json:"openInterest" csv:"openInterest"`
+	Bid               float64      `json:"bid" csv:"bid"`
+	Ask               float64      `json:"ask" csv:"ask"`
+	Size              string       `json:"contractSize" csv:"contractSize"`
+	Expiration        int          `json:"expiration" csv:"expiration"`
+	LastTradeDate     int          `json:"lastTradeDate" csv:"lastTradeDate"`
+	ImpliedVolatility float64      `json:"impliedVolatility" csv:"impliedVolatility"`
+	InTheMoney        bool         `json:"inTheMoney" csv:"inTheMoney"`
+}
+
+// StreamQuote is a live price update pushed by the yahoo streamer.
+type StreamQuote struct {
+	Symbol string  `json:"id" csv:"id"`
+	Price  float64 `json:"price" csv:"price"`
+	// Time is the time of the update in unix milliseconds.
+	Time          int64   `json:"time" csv:"time"`
+	Currency      string  `json:"currency" csv:"currency"`
+	Exchange      string  `json:"exchange" csv:"exchange"`
+	QuoteType     int     `json:"quoteType" csv:"quoteType"`
+	MarketHours   int     `json:"marketHours" csv:"marketHours"`
+	ChangePercent float64 `json:"changePercent" csv:"changePercent"`
+	DayVolume     int64   `json:"dayVolume" csv:"dayVolume"`
+	DayHigh       float64 `json:"dayHigh" csv:"dayHigh"`
+	DayLow        float64 `json:"dayLow" csv:"dayLow"`
+	Change        float64 `json:"change" csv:"change"`
+	ShortName     string  `json:"shortName" csv:"shortName"`
+	OpenPrice     float64 `json:"openPrice" csv:"openPrice"`
+	PreviousClose float64 `json:"previousClose" csv:"previousClose"`
+	LastSize      int64   `json:"lastSize" csv:"lastSize"`
+	Bid           float64 `json:"bid" csv:"bid"`
+	BidSize       int64   `json:"bidSize" csv:"bidSize"`
+	Ask           float64 `json:"ask" csv:"ask"`
+	AskSize       int64   `json:"askSize" csv:"askSize"`
+}
+
+// IVSurface is a grid of implied volatilities for the options on an
+// underlier. Calls and Puts are indexed by expiration and strike,
+// following the order of Expirations and Strikes. Strikes not listed
+// for an expiration are nil rather than zero.
+type IVSurface struct {
+	UnderlyingSymbol string       `json:"underlyingSymbol"`
+	Expirations      []int        `json:"expirations"`
+	Strikes          []float64    `json:"strikes"`
+	Calls            [][]*float64 `json:"calls"`
+	Puts             [][]*float64 `json:"puts"`
+}
+
+// Greeks are the Black-Scholes sensitivities of an option contract.
+// Theta is the change in value per day, while Vega and Rho are the
+// changes in value for a 1% move in volatility and interest rate.
+type Greeks struct {
+	Delta float64 `json:"delta" csv:"delta"`
+	Gamma float64 `json:"gamma" csv:"gamma"`
+	Theta float64 `json:"theta" csv:"theta"`
+	Vega  float64 `json:"vega" csv:"vega"`
+	Rho   float64 `json:"rho" csv:"rho"`
+}
+
+// SpreadType is the kind of a vertical spread.
+type SpreadType string
+
+const (
+	// SpreadTypeBullCall buys a call and sells a higher strike call.
+	SpreadTypeBullCall SpreadType = "bull_call"
+	// SpreadTypeBearCall sells a call and buys a higher strike call.
+	SpreadTypeBearCall SpreadType = "bear_call"
+	// SpreadTypeBullPut sells a put and buys a lower strike put.
+	SpreadTypeBullPut SpreadType = "bull_put"
+	// SpreadTypeBearPut buys a put and sells a lower strike put.
+	SpreadTypeBearPut SpreadType = "bear_put"
+)
+
+// VerticalSpread is a spread of two contracts of the same type and
+// expiration at different strikes. Amounts are per share, with the
+// long leg bought at its ask and the short leg sold at its bid.
+type VerticalSpread struct {
+	Type  SpreadType `json:"type" csv:"type"`
+	Long  *Contract  `json:"long" csv:"long_,inline"`
+	Short *Contract  `json:"short" csv:"short_,inline"`
+	// Width is the difference between the strikes.
+	Width float64 `json:"width" csv:"width"`
+	// Net is the credit received when positive,
+	// or the debit paid when negative.
+	Net       float64 `json:"net" csv:"net"`
+	MaxProfit float64 `json:"maxProfit" csv:"maxProfit"`
+	MaxLoss   float64 `json:"maxLoss" csv:"maxLoss"`
+	Breakeven float64 `json:"breakeven" csv:"breakeven"`
+}
+
+// QuoteSummary is a collection of quote summary modules
+// for a single symbol. Only the requested modules are set.
+type QuoteSummary struct {
+	Symbol        string         `json:"-"`
+	AssetProfile  *AssetProfile  `json:"assetProfile,omitempty"`
+	SummaryDetail *SummaryDetail `json:"summaryDetail,omitempty"`
+	FinancialData *FinancialData `json:"financialData,omitempty"`
+	QuoteType     *QuoteTypeInfo `json:"quoteType,omitempty"`
+
+	// Financial statement histories.
+	IncomeStatementHistory            *StatementHistory `json:"incomeStatementHistory,omitempty"`
+	IncomeStatementHistoryQuarterly   *StatementHistory `json:"incomeStatementHistoryQuarterly,omitempty"`
+	BalanceSheetHistory               *StatementHistory `json:"balanceSheetHistory,omitempty"`
+	BalanceSheetHistoryQuarterly      *StatementHistory `json:"balanceSheetHistoryQuarterly,omitempty"`
+	CashflowStatementHistory          *StatementHistory `json:"cashflowStatementHistory,omitempty"`
+	CashflowStatementHistoryQuarterly *StatementHistory `json:"cashflowStatementHistoryQuarterly,omitempty"`
+
+	// Earnings.
+	CalendarEvents *CalendarEvents `json:"calendarEvents,omitempty"`
+	Earnings       *Earnings       `json:"earnings,omitempty"`
+
+	// Analyst recommendations.
+	RecommendationTrend     *RecommendationTrend     `json:"recommendationTrend,omitempty"`
+	UpgradeDowngradeHistory *UpgradeDowngradeHistory `json:"upgradeDowngradeHistory,omitempty"`
+
+	// Sustainability.
+	ESGScores *ESGScore `json:"esgScores,omitempty"`
+
+	// Ownership.
+	InsiderTransactions   *InsiderTransactions   `json:"insiderTransactions,omitempty"`
+	InstitutionOwnership  *InstitutionOwnership  `json:"institutionOwnership,omitempty"`
+	MajorHoldersBreakdown *MajorHoldersBreakdown `json:"majorHoldersBreakdown,omitempty"`
+
+	// Statistics.
+	DefaultKeyStatistics *KeyStatistics `json:"defaultKeyStatistics,omitempty"`
+
+	// Funds.
+	FundProfile     *FundProfile     `json:"fundProfile,omitempty"`
+	TopHoldings     *TopHoldings     `json:"topHoldings,omitempty"`
+	FundPerformance *FundPerformance `json:"fundPerformance,omitempty"`
+
+	// Futures.
+	FuturesChain *FuturesChain `json:"futuresChain,omitempty"`
+
+	// Indices.
+	Components *IndexComponents `json:"components,omitempty"`
+
+	// Filings.
+	SECFilings *SECFilings `json:"secFilings,omitempty"`
+}
+
+// SECFilings are the sec filings of a company.
+type SECFilings struct {
+	Filings []struct {
+		EpochDate int    `json:"epochDate"`
+		Type      string `json:"type"`
+		Title     string `json:"title"`
+		EdgarURL  string `json:"edgarUrl"`
+	} `json:"filings"`
+}
+
+// SECFiling is a document filed with the sec, such as a 10-K.
+type SECFiling struct {
+	Type     string
+	Title    string
+	Date     datetime.Datetime
+	EdgarURL string
+}
+
+// IndexComponents lists the constituent symbols of an index.
+type IndexComponents struct {
+	Components []string `json:"components"`
+}
+
+// FuturesChain lists the contract symbols of a futures root.
+type FuturesChain struct {
+	Futures []string `json:"futures"`
+}
+
+// AssetProfile is the company profile of a symbol.
+type AssetProfile struct {
+	Symbol              string            `json:"-"`
+	Address1            string            `json:"address1"`
+	Address2            string            `json:"address2"`
+	City                string            `json:"city"`
+	State               string            `json:"state"`
+	Zip                 string            `json:"zip"`
+	Country             string            `json:"country"`
+	Phone               string            `json:"phone"`
+	Website             string            `json:"website"`
+	Industry            string            `json:"industry"`
+	Sector              string            `json:"sector"`
+	LongBusinessSummary string            `json:"longBusinessSummary"`
+	FullTimeEmployees   int               `json:"fullTimeEmployees"`
+	Officers            []*CompanyOfficer `json:"companyOfficers"`
+}
+
+// CompanyOfficer is an executive listed in a company profile.
+type CompanyOfficer struct {
+	Name       string `json:"name"`
+	Title      string `json:"title"`
+	Age        int    `json:"age"`
+	YearBorn   int    `json:"yearBorn"`
+	FiscalYear int    `json:"fiscalYear"`
+	// TotalPay is zero if not reported.
+	TotalPay float64 `json:"totalPay"`
+}
+
+// SummaryDetail is the trading summary of a symbol.
+type SummaryDetail struct {
+	PreviousClose                float64 `json:"previousClose"`
+	Open                         float64 `json:"open"`
+	DayLow                       float64 `json:"dayLow"`
+	DayHigh                      float64 `json:"dayHigh"`
+	DividendRate                 float64 `json:"dividendRate"`
+	DividendYield                float64 `json:"dividendYield"`
+	ExDividendDate               int     `json:"exDividendDate"`
+	PayoutRatio                  float64 `json:"payoutRatio"`
+	FiveYearAvgDividendYield     float64 `json:"fiveYearAvgDividendYield"`
+	Beta                         float64 `json:"beta"`
+	TrailingPE                   float64 `json:"trailingPE"`
+	ForwardPE                    float64 `json:"forwardPE"`
+	Volume                       int64   `json:"volume"`
+	AverageVolume                int64   `json:"averageVolume"`
+	AverageVolume10Days          int64   `json:"averageVolume10days"`
+	Bid                          float64 `json:"bid"`
+	Ask                          float64 `json:"ask"`
+	BidSize                      int     `json:"bidSize"`
+	AskSize                      int     `json:"askSize"`
+	MarketCap                    int64   `json:"marketCap"`
+	FiftyTwoWeekLow              float64 `json:"fiftyTwoWeekLow"`
+	FiftyTwoWeekHigh             float64 `json:"fiftyTwoWeekHigh"`
+	PriceToSalesTrailing12Months float64 `json:"priceToSalesTrailing12Months"`
+	FiftyDayAverage              float64 `json:"fiftyDayAverage"`
+	TwoHundredDayAverage         float64 `json:"twoHundredDayAverage"`
+	TrailingAnnualDividendRate   float64 `json:"trailingAnnualDividendRate"`
+	TrailingAnnualDividendYield  float64 `json:"trailingAnnualDividendYield"`
+	Currency                     string  `json:"currency"`
+}
+
+// FinancialData is the current financial data of a symbol.
+type FinancialData struct {
+	CurrentPrice            float64 `json:"currentPrice"`
+	TargetHighPrice         float64 `json:"targetHighPrice"`
+	TargetLowPrice          float64 `json:"targetLowPrice"`
+	TargetMeanPrice         float64 `json:"targetMeanPrice"`
+	TargetMedianPrice       float64 `json:"targetMedianPrice"`
+	RecommendationMean      float64 `json:"recommendationMean"`
+	RecommendationKey       string  `json:"recommendationKey"`
+	NumberOfAnalystOpinions int     `json:"numberOfAnalystOpinions"`
+	TotalCash               int64   `json:"totalCash"`
+	TotalCashPerShare       float64 `json:"totalCashPerShare"`
+	Ebitda                  int64   `json:"ebitda"`
+	TotalDebt               int64   `json:"totalDebt"`
+	QuickRatio              float64 `json:"quickRatio"`
+	CurrentRatio            float64 `json:"currentRatio"`
+	TotalRevenue            int64   `json:"totalRevenue"`
+	DebtToEquity            float64 `json:"debtToEquity"`
+	RevenuePerShare         float64 `json:"revenuePerShare"`
+	ReturnOnAssets          float64 `json:"returnOnAssets"`
+	ReturnOnEquity          float64 `json:"returnOnEquity"`
+	GrossProfits            int64   `json:"grossProfits"`
+	FreeCashflow            int64   `json:"freeCashflow"`
+	OperatingCashflow       int64   `json:"operatingCashflow"`
+	EarningsGrowth          float64 `json:"earningsGrowth"`
+	RevenueGrowth           float64 `json:"revenueGrowth"`
+	GrossMargins            float64 `json:"grossMargins"`
+	EbitdaMargins           float64 `json:"ebitdaMargins"`
+	OperatingMargins        float64 `json:"operatingMargins"`
+	ProfitMargins           float64 `json:"profitMargins"`
+	FinancialCurrency       string  `json:"financialCurrency"`
+}
+
+// StatementHistory is a history of financial statements as returned by
+// the statement quote summary modules. Each statement maps line item
+// names, such as totalRevenue or endDate, to their values.
+type StatementHistory struct {
+	Statements []map[string]interface{} `json:"-"`
+}
+
+// UnmarshalJSON decodes a statement history, which yahoo nests
+// under a different key for each kind of statement.
+func (h *StatementHistory) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for _, key := range []string{"incomeStatementHistory", "balanceSheetStatements", "cashflowStatements"} {
+		if statements, ok := raw[key]; ok {
+			return json.Unmarshal(statements, &h.Statements)
+		}
+	}
+	return nil
+}
+
+// StatementFrequency is the reporting period of a financial statement.
+type StatementFrequency string
+
+const (
+	// StatementFrequencyAnnual annual financial statements.
+	StatementFrequencyAnnual StatementFrequency = "annual"
+	// StatementFrequencyQuarterly quarterly financial statements.
+	StatementFrequencyQuarterly StatementFrequency = "quarterly"
+)
+
+// FinancialStatement is a single income statement,
+// balance sheet or cash flow statement.
+type FinancialStatement struct {
+	EndDate   datetime.Datetime
+	Frequency StatementFrequency
+	// LineItems maps line item names to their values.
+	// Items that weren't reported are absent.
+	LineItems map[string]*float64
+}
+
+// CalendarEvents are the upcoming earnings
+// and dividend events of a symbol.
+type CalendarEvents struct {
+	Earnings       CalendarEarnings `json:"earnings"`
+	ExDividendDate int              `json:"exDividendDate"`
+	DividendDate   int              `json:"dividendDate"`
+}
+
+// CalendarEarnings are the estimates for the next earnings report.
+type CalendarEarnings struct {
+	// EarningsDate holds the start and end of the
+	// window in which earnings are expected.
+	EarningsDate    []int    `json:"earningsDate"`
+	EarningsAverage *float64 `json:"earningsAverage"`
+	EarningsLow     *float64 `json:"earningsLow"`
+	EarningsHigh    *float64 `json:"earningsHigh"`
+	RevenueAverage  *int64   `json:"revenueAverage"`
+	RevenueLow      *int64   `json:"revenueLow"`
+	RevenueHigh     *int64   `json:"revenueHigh"`
+}
+
+// Earnings is the earnings history of a symbol.
+type Earnings struct {
+	EarningsChart struct {
+		Quarterly                  []QuarterlyEarnings `json:"quarterly"`
+		CurrentQuarterEstimate     *float64            `json:"currentQuarterEstimate"`
+		CurrentQuarterEstimateDate string              `json:"currentQuarterEstimateDate"`
+		CurrentQuarterEstimateYear int                 `json:"currentQuarterEstimateYear"`
+	} `json:"earningsChart"`
+}
+
+// QuarterlyEarnings is the actual and estimated eps of a quarter.
+type QuarterlyEarnings struct {
+	// Quarter is the fiscal quarter, such as 4Q2017.
+	Quarter  string   `json:"date"`
+	Actual   *float64 `json:"actual"`
+	Estimate *float64 `json:"estimate"`
+}
+
+// EarningsCalendar is the next earnings report
+// and the earnings history of a symbol.
+type EarningsCalendar struct {
+	Symbol string
+	// EarningsStart and EarningsEnd bound the window in which the
+	// next earnings are expected. They are nil if none are scheduled.
+	EarningsStart *datetime.Datetime
+	EarningsEnd   *datetime.Datetime
+	// EPSEstimate is the average estimated eps
+	// for the next report, if available.
+	EPSEstimate *float64
+	// History is the quarterly eps actual vs estimate
+	// series, oldest first.
+	History []QuarterlyEarnings
+}
+
+// RecommendationTrend is the analyst recommendation
+// counts of a symbol over recent months.
+type RecommendationTrend struct {
+	Symbol string                 `json:"-"`
+	Trend  []RecommendationPeriod `json:"trend"`
+}
+
+// RecommendationPeriod is the number of analyst
+// recommendations of each grade in a period.
+type RecommendationPeriod struct {
+	// Period is relative to the current month,
+	// such as "0m" for the current month and
+	// "-1m" for the month before.
+	Period     string `json:"period"`
+	StrongBuy  int    `json:"strongBuy"`
+	Buy        int    `json:"buy"`
+	Hold       int    `json:"hold"`
+	Sell       int    `json:"sell"`
+	StrongSell int    `json:"strongSell"`
+}
+
+// PriceTarget is the consensus analyst price target of a symbol.
+type PriceTarget struct {
+	Symbol                  string
+	TargetHigh              float64
+	TargetLow               float64
+	TargetMean              float64
+	TargetMedian            float64
+	NumberOfAnalystOpinions int
+	// HasCoverage is false if no analysts cover the symbol,
+	// in which case the target fields are zero.
+	HasCoverage bool
+}
+
+// SearchResult is the result of a symbol search.
+type SearchResult struct {
+	Count  int           `json:"count"`
+	Quotes []SearchQuote `json:"quotes"`
+	News   []SearchNews  `json:"news"`
+}
+
+// SearchQuote is a quote matched by a symbol search.
+type SearchQuote struct {
+	Symbol    string    `json:"symbol"`
+	ShortName string    `json:"shortname"`
+	LongName  string    `json:"longname"`
+	Exchange  string    `json:"exchange"`
+	ExchDisp  string    `json:"exchDisp"`
+	QuoteType QuoteType `json:"quoteType"`
+	TypeDisp  string    `json:"typeDisp"`
+	Sector    string    `json:"sector"`
+	Industry  string    `json:"industry"`
+	Score     float64   `json:"score"`
+}
+
+// SearchNews is a news story matched by a symbol search.
+type SearchNews struct {
+	UUID                string            `json:"uuid"`
+	Title               string            `json:"title"`
+	Publisher           string            `json:"publisher"`
+	Link                string            `json:"link"`
+	ProviderPublishTime datetime.Datetime `json:"providerPublishTime"`
+	Type                string            `json:"type"`
+	RelatedTickers      []string          `json:"relatedTickers"`
+}
+
+// TrendingResult is the list of trending symbols in a region.
+type TrendingResult struct {
+	Region string
+	Count  int
+	// Symbols are ordered from most to least trending.
+	Symbols []string
+}
+
+// NewsArticle is a news story about one or more symbols.
+type NewsArticle struct {
+	UUID           string            `json:"uuid"`
+	Title          string            `json:"title"`
+	Publisher      string            `json:"publisher"`
+	Link           string            `json:"link"`
+	PublishTime    datetime.Datetime `json:"publishTime"`
+	RelatedTickers []string          `json:"relatedTickers"`
+}
+
+// ESGScore is the environmental, social and
+// governance risk score of a symbol.
+type ESGScore struct {
+	Symbol           string  `json:"-"`
+	TotalESG         float64 `json:"totalEsg"`
+	Environment      float64 `json:"environmentScore"`
+	Social           float64 `json:"socialScore"`
+	Governance       float64 `json:"governanceScore"`
+	Percentile       float64 `json:"percentile"`
+	ControversyLevel int     `json:"highestControversy"`
+	PeerGroup        string  `json:"peerGroup"`
+	RatingYear       int     `json:"ratingYear"`
+	RatingMonth      int     `json:"ratingMonth"`
+}
+
+// InsiderTransactions is the list of reported insider transactions of a symbol.
+type InsiderTransactions struct {
+	Transactions []InsiderTransaction `json:"transactions"`
+}
+
+// InsiderTransaction is a single reported insider transaction.
+type InsiderTransaction struct {
+	FilerName     string `json:"filerName"`
+	FilerRelation string `json:"filerRelation"`
+	FilerURL      string `json:"filerUrl"`
+	// TransactionText describes the transaction, such as
+	// "Sale at price 150.00 per share.".
+	TransactionText string            `json:"transactionText"`
+	MoneyText       string            `json:"moneyText"`
+	Ownership       string            `json:"ownership"`
+	Shares          int64             `json:"shares"`
+	Value           float64           `json:"value"`
+	StartDate       datetime.Datetime `json:"startDate"`
+	// IsAcquisition is true if the insider acquired shares,
+	// such as through a purchase, award or option exercise,
+	// and false if they disposed of them.
+	IsAcquisition bool `json:"-"`
+}
+
+// InstitutionOwnership is the list of institutional holders of a symbol.
+type InstitutionOwnership struct {
+	OwnershipList []Holder `json:"ownershipList"`
+}
+
+// Holder is a single institutional holder of a symbol.
+type Holder struct {
+	Organization  string            `json:"organization"`
+	ReportDate    datetime.Datetime `json:"reportDate"`
+	PercentHeld   float64           `json:"pctHeld"`
+	SharesHeld    int64             `json:"position"`
+	Value         int64             `json:"value"`
+	PercentChange float64           `json:"pctChange"`
+}
+
+// MajorHoldersBreakdown is the breakdown of the
+// ownership of a symbol by holder type.
+type MajorHoldersBreakdown struct {
+	Symbol                       string  `json:"-"`
+	InsidersPercentHeld          float64 `json:"insidersPercentHeld"`
+	InstitutionsPercentHeld      float64 `json:"institutionsPercentHeld"`
+	InstitutionsFloatPercentHeld float64 `json:"institutionsFloatPercentHeld"`
+	InstitutionsCount            int     `json:"institutionsCount"`
+}
+
+// KeyStatistics are the key valuation and share statistics of a symbol.
+// Fields are nil if they weren't reported.
+type KeyStatistics struct {
+	Symbol                  string   `json:"-"`
+	EnterpriseValue         *int64   `json:"enterpriseValue"`
+	ForwardPE               *float64 `json:"forwardPE"`
+	PegRatio                *float64 `json:"pegRatio"`
+	PriceToBook             *float64 `json:"priceToBook"`
+	BookValue               *float64 `json:"bookValue"`
+	Beta                    *float64 `json:"beta"`
+	ProfitMargins           *float64 `json:"profitMargins"`
+	TrailingEps             *float64 `json:"trailingEps"`
+	ForwardEps              *float64 `json:"forwardEps"`
+	EnterpriseToRevenue     *float64 `json:"enterpriseToRevenue"`
+	EnterpriseToEbitda      *float64 `json:"enterpriseToEbitda"`
+	FiftyTwoWeekChange      *float64 `json:"52WeekChange"`
+	SharesOutstanding       *int64   `json:"sharesOutstanding"`
+	FloatShares             *int64   `json:"floatShares"`
+	SharesShort             *int64   `json:"sharesShort"`
+	ShortRatio              *float64 `json:"shortRatio"`
+	ShortPercentOfFloat     *float64 `json:"shortPercentOfFloat"`
+	HeldPercentInsiders     *float64 `json:"heldPercentInsiders"`
+	HeldPercentInstitutions *float64 `json:"heldPercentInstitutions"`
+	LastSplitFactor         *string  `json:"lastSplitFactor"`
+	LastSplitDate           *int     `json:"lastSplitDate"`
+}
+
+// QuoteTypeInfo is the classification and exchange timezone of a symbol.
+type QuoteTypeInfo struct {
+	Symbol                 string    `json:"symbol"`
+	UnderlyingSymbol       string    `json:"underlyingSymbol"`
+	ShortName              string    `json:"shortName"`
+	LongName               string    `json:"longName"`
+	Exchange               string    `json:"exchange"`
+	QuoteType              QuoteType `json:"quoteType"`
+	FirstTradeDateEpochUtc int       `json:"firstTradeDateEpochUtc"`
+	TimeZoneFullName       string    `json:"timeZoneFullName"`
+	TimeZoneShortName      string    `json:"timeZoneShortName"`
+	GMTOffSetMilliseconds  int       `json:"gmtOffSetMilliseconds"`
+}
+
+// UpgradeDowngradeHistory is the history of analyst rating changes of a symbol.
+type UpgradeDowngradeHistory struct {
+	History []struct {
+		EpochGradeDate int              `json:"epochGradeDate"`
+		Firm           string           `json:"firm"`
+		ToGrade        string           `json:"toGrade"`
+		FromGrade      string           `json:"fromGrade"`
+		Action         RatingActionType `json:"action"`
+	} `json:"history"`
+}
+
+// RatingActionType alias for the kind of analyst rating change.
+type RatingActionType string
+
+const (
+	// RatingActionUpgrade the rating was upgraded.
+	RatingActionUpgrade RatingActionType = "up"
+	// RatingActionDowngrade the rating was downgraded.
+	RatingActionDowngrade RatingActionType = "down"
+	// RatingActionInitiate coverage was initiated.
+	RatingActionInitiate RatingActionType = "init"
+	// RatingActionMaintain the rating was maintained.
+	RatingActionMaintain RatingActionType = "main"
+	// RatingActionReiterate the rating was reiterated.
+	RatingActionReiterate RatingActionType = "reit"
+)
+
+// RatingAction is a single analyst rating change.
+type RatingAction struct {
+	Firm      string
+	FromGrade string
+	ToGrade   string
+	Action    RatingActionType
+	// GradeDate is the date of the change
+	// in the exchange's timezone.
+	GradeDate datetime.Datetime
+}
+
+// ChartEvents are the corporate events
+// returned alongside a chart, keyed by date.
+type ChartEvents struct {
+	Dividends    map[string]*Dividend    `json:"dividends"`
+	Splits       map[string]*Split       `json:"splits"`
+	CapitalGains map[string]*CapitalGain `json:"capitalGains"`
+}
+
+// Dividend is a single dividend distribution.
+type Dividend struct {
+	Amount float64 `json:"amount"`
+	// Date is the ex-dividend date.
+	Date datetime.Datetime `json:"date"`
+}
+
+// Split is a single stock split.
+type Split struct {
+	Numerator   float64 `json:"numerator"`
+	Denominator float64 `json:"denominator"`
+	// Ratio is the split ratio as reported, such as "4:1".
+	Ratio string            `json:"splitRatio"`
+	Date  datetime.Datetime `json:"date"`
+}
+
+// CapitalGain is a single capital gains distribution of a fund.
+type CapitalGain struct {
+	Amount float64           `json:"amount"`
+	Date   datetime.Datetime `json:"date"`
+}
+
+// FundProfile is the profile of a fund.
+type FundProfile struct {
+	Family       string `json:"family"`
+	CategoryName string `json:"categoryName"`
+	LegalType    string `json:"legalType"`
+	// Fees are the fund's own fees and expenses.
+	Fees *FundFees `json:"feesExpensesInvestment"`
+}
+
+// FundFees are the fees and expenses of a fund. Expense ratios are
+// fractions, so 0.0003 is 0.03%. Funds report the net and gross
+// ratios separately when fees are waived or reimbursed.
+type FundFees struct {
+	AnnualReportExpenseRatio *float64 `json:"annualReportExpenseRatio"`
+	NetExpenseRatio          *float64 `json:"netExpRatio"`
+	GrossExpenseRatio        *float64 `json:"grossExpRatio"`
+	AnnualHoldingsTurnover   *float64 `json:"annualHoldingsTurnover"`
+	TotalNetAssets           *float64 `json:"totalNetAssets"`
+}
+
+// TopHoldings are the largest positions and
+// the asset allocation of a fund.
+type TopHoldings struct {
+	StockPosition *float64       `json:"stockPosition"`
+	BondPosition  *float64       `json:"bondPosition"`
+	CashPosition  *float64       `json:"cashPosition"`
+	OtherPosition *float64       `json:"otherPosition"`
+	Holdings      []*FundHolding `json:"holdings"`
+	// SectorWeightings and BondRatings are reported
+	// as lists of single entry maps.
+	SectorWeightings []map[string]float64 `json:"sectorWeightings"`
+	BondRatings      []map[string]float64 `json:"bondRatings"`
+}
+
+// FundHolding is a single position of a fund.
+type FundHolding struct {
+	Symbol string `json:"symbol"`
+	Name   string `json:"holdingName"`
+	// Percent is the fraction of the fund's assets held.
+	Percent float64 `json:"holdingPercent"`
+}
+
+// ETFProfile is the profile and holdings breakdown of an etf.
+// Funds without holdings data have empty holdings and weightings.
+type ETFProfile struct {
+	Symbol       string
+	Family       string
+	Category     string
+	ExpenseRatio *float64
+	// TotalNetAssets are the fund's assets in its currency.
+	TotalNetAssets *float64
+	StockPosition  *float64
+	BondPosition   *float64
+	CashPosition   *float64
+	Holdings       []*FundHolding
+	// SectorWeightings are fractions keyed by sector, e.g. "technology".
+	SectorWeightings map[string]float64
+	// BondRatings are fractions keyed by rating, e.g. "aaa".
+	BondRatings map[string]float64
+}
+
+// FundPerformance is the return history of a fund.
+type FundPerformance struct {
+	TrailingReturns    *TrailingReturns    `json:"trailingReturns"`
+	AnnualTotalReturns *AnnualTotalReturns `json:"annualTotalReturns"`
+}
+
+// TrailingReturns are the total returns of a fund over
+// trailing periods, as fractions. Longer periods are annualized.
+type TrailingReturns struct {
+	AsOfDate   datetime.Datetime `json:"asOfDate"`
+	YTD        *float64          `json:"ytd"`
+	OneMonth   *float64          `json:"oneMonth"`
+	ThreeMonth *float64          `json:"threeMonth"`
+	OneYear    *float64          `json:"oneYear"`
+	ThreeYear  *float64          `json:"threeYear"`
+	FiveYear   *float64          `json:"fiveYear"`
+	TenYear    *float64          `json:"tenYear"`
+}
+
+// AnnualTotalReturns are the calendar year returns of a fund.
+type AnnualTotalReturns struct {
+	Returns []*AnnualReturn `json:"returns"`
+}
+
+// AnnualReturn is the total return of a fund for a calendar year.
+type AnnualReturn struct {
+	Year int `json:"year,string"`
+	// Return is nil for years without a reported return.
+	Return *float64 `json:"annualValue"`
+}
+
+// MutualFundProfile is the profile and performance of a mutual fund.
+// Yahoo doesn't report management fees on their own; ExpenseRatio is
+// the total expense ratio from the fund's annual report.
+type MutualFundProfile struct {
+	Symbol            string
+	Family            string
+	Category          string
+	ExpenseRatio      *float64
+	NetExpenseRatio   *float64
+	GrossExpenseRatio *float64
+	Turnover          *float64
+	TotalNetAssets    *float64
+	TrailingReturns   *TrailingReturns
+	// AnnualTotalReturns are ordered by year, most recent first.
+	AnnualTotalReturns []*AnnualReturn
+}
+
+// Spark is a lightweight close price series of a symbol.
+type Spark struct {
+	Symbol string
+	// Timestamps are the unix times of the closes.
+	Timestamps    []int
+	Close         []float64
+	PreviousClose float64
+}
+
+// TradingSession is a trading session of an exchange.
+type TradingSession struct {
+	Start time.Time
+	End   time.Time
+}
+
+// MarketHours are the current trading sessions of an exchange, in the
+// exchange timezone. Shortened sessions, such as half-days, are
+// reported as such.
+type MarketHours struct {
+	Exchange string
+	Timezone string
+	Pre      TradingSession
+	Regular  TradingSession
+	Post     TradingSession
 }